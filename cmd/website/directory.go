@@ -0,0 +1,29 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/purdoobahs/purdoobahs.com/internal/alumni"
+)
+
+// alumniFilter reads the alumni directory's filter from the query string.
+func alumniFilter(r *http.Request) (*alumni.Filter, error) {
+	query := r.URL.Query()
+	filter := &alumni.Filter{
+		Industry: strings.TrimSpace(query.Get("industry")),
+		State:    strings.TrimSpace(query.Get("state")),
+	}
+
+	if graduationYear := strings.TrimSpace(query.Get("graduation_year")); graduationYear != "" {
+		year, err := strconv.Atoi(graduationYear)
+		if err != nil {
+			return filter, fmt.Errorf("invalid graduation year: `%s`", graduationYear)
+		}
+		filter.GraduationYear = year
+	}
+
+	return filter, nil
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"runtime/debug"
+	"strings"
+
+	"github.com/purdoobahs/purdoobahs.com/internal/achievements"
+	"github.com/purdoobahs/purdoobahs.com/internal/apierror"
+	"github.com/purdoobahs/purdoobahs.com/internal/cachecontrol"
+	"github.com/purdoobahs/purdoobahs.com/internal/cravers"
+	"github.com/purdoobahs/purdoobahs.com/internal/events"
+	"github.com/purdoobahs/purdoobahs.com/internal/httpheader"
+	"github.com/purdoobahs/purdoobahs.com/internal/logger"
+	"github.com/purdoobahs/purdoobahs.com/internal/mimetype"
+	"github.com/purdoobahs/purdoobahs.com/internal/purdoobahs"
+	"github.com/purdoobahs/purdoobahs.com/internal/requestid"
+	"github.com/purdoobahs/purdoobahs.com/internal/toobahsassins"
+	"github.com/purdoobahs/purdoobahs.com/internal/traditions"
+)
+
+// isAPIRequest returns whether the request was made to the JSON API, whose errors are always rendered as JSON.
+func isAPIRequest(r *http.Request) bool {
+	return r.URL.Path == "/api" || strings.HasPrefix(r.URL.Path, "/api/")
+}
+
+func (app *application) apiNotFound(w http.ResponseWriter, r *http.Request) {
+	app.apiError(w, r, apierror.NotFound(fmt.Sprintf("no API endpoint exists at `%s`", r.URL.Path)))
+}
+
+func (app *application) tooManyRequests(w http.ResponseWriter, r *http.Request) {
+	if isAPIRequest(r) {
+		app.apiError(w, r, apierror.TooManyRequests())
+		return
+	}
+	app.clientError(w, r, http.StatusTooManyRequests)
+}
+
+func (app *application) methodNotAllowed(w http.ResponseWriter, r *http.Request) {
+	if isAPIRequest(r) {
+		app.apiError(w, r, apierror.MethodNotAllowed(r.Method))
+		return
+	}
+	app.clientError(w, r, http.StatusMethodNotAllowed)
+}
+
+func (app *application) csrfFailure(w http.ResponseWriter, r *http.Request) {
+	app.clientError(w, r, http.StatusForbidden)
+}
+
+// apiServiceError maps an error returned by a service to the matching API error.
+//
+// Errors that aren't one of the known sentinel errors are treated as internal server errors.
+func (app *application) apiServiceError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, purdoobahs.ErrPurdoobahNotFound),
+		errors.Is(err, purdoobahs.ErrSectionNotFound),
+		errors.Is(err, traditions.ErrTraditionNotFound),
+		errors.Is(err, cravers.ErrCraverNotFound),
+		errors.Is(err, achievements.ErrAchievementNotFound),
+		errors.Is(err, events.ErrEventNotFound),
+		errors.Is(err, toobahsassins.ErrGameNotFound):
+		app.apiError(w, r, apierror.NotFound(err.Error()))
+	default:
+		app.serveError(w, r, err)
+	}
+}
+
+// apiError writes the given error inside of the standard API error envelope.
+func (app *application) apiError(w http.ResponseWriter, r *http.Request, apiErr *apierror.Error) {
+	if apiErr.Code == "" {
+		apiErr.Code = apierror.CodeOf(apiErr.Status)
+	}
+	apiErr.RequestID = requestid.Get(r)
+	app.writeJSON(w, r, apiErr.Status, &apierror.Envelope{Error: apiErr})
+}
+
+// writeJSON marshals v and sends it out with the given status code.
+func (app *application) writeJSON(w http.ResponseWriter, r *http.Request, status int, v interface{}) {
+	// convert to JSON bytes
+	b, err := json.Marshal(v)
+	if err != nil {
+		app.serveError(w, r, err)
+		return
+	}
+
+	// send it out
+	w.Header().Set(
+		httpheader.ContentType.String(),
+		fmt.Sprintf("%s; charset=utf-8", mimetype.Json.String()),
+	)
+	w.WriteHeader(status)
+	_, err = w.Write(b)
+	if err != nil {
+		app.logger.Error(err.Error())
+	}
+}
+
+func (app *application) serveError(w http.ResponseWriter, r *http.Request, err error) {
+	trace := fmt.Sprintf("%s (request %s)\n%s", err.Error(), requestid.Get(r), debug.Stack())
+	err = app.logger.(*logger.Logger).ErrorLog.Output(2, trace)
+	if err != nil {
+		app.serveError(w, r, err)
+		return
+	}
+
+	// errors mustn't be kept by browsers or CDNs, even from routes that are cached forever (e.g. /image)
+	cachecontrol.SetNoCache(w)
+
+	if isAPIRequest(r) {
+		app.apiError(w, r, apierror.Internal())
+		return
+	}
+	http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+}
+
+func (app *application) clientError(w http.ResponseWriter, r *http.Request, status int) {
+	// same as serveError
+	cachecontrol.SetNoCache(w)
+
+	if isAPIRequest(r) {
+		app.apiError(w, r, apierror.FromStatus(status))
+		return
+	}
+	http.Error(w, http.StatusText(status), status)
+}
@@ -0,0 +1,163 @@
+package main
+
+import (
+	"fmt"
+	"html/template"
+	"strings"
+	"time"
+
+	"github.com/purdoobahs/purdoobahs.com/internal/events"
+	"github.com/purdoobahs/purdoobahs.com/internal/ical"
+	"github.com/purdoobahs/purdoobahs.com/internal/rsvp"
+	"github.com/purdoobahs/purdoobahs.com/internal/traditions"
+)
+
+// calendarRefreshInterval is how often subscribers are asked to check the calendar for changes.
+const calendarRefreshInterval = 12 * time.Hour
+
+// eventSchedule is every event, split into the ones that haven't happened yet and the ones that have.
+type eventSchedule struct {
+	Upcoming []*eventView
+	Past     []*eventView
+
+	// CalendarURL is the webcal:// link that calendar apps subscribe to. html/template doesn't trust the webcal
+	// scheme, so it's marked as safe.
+	CalendarURL template.URL
+}
+
+// eventView is an event with its traditions looked up, for rendering.
+type eventView struct {
+	Event      *events.Event
+	Traditions []*traditions.Tradition
+
+	// RSVPs and Seats are only filled in for events that take RSVPs, by withRSVPs.
+	RSVPs []*rsvp.Entry
+	Seats *rsvp.Summary
+
+	// TakesRSVPs is whether the RSVP form is shown, as of when the page was rendered.
+	TakesRSVPs bool
+}
+
+// newEventSchedule splits the events (earliest first) into upcoming ones, soonest first, and past ones, most recent
+// first.
+func (app *application) newEventSchedule(allEvents []*events.Event, now time.Time) *eventSchedule {
+	schedule := &eventSchedule{
+		Upcoming:    make([]*eventView, 0),
+		Past:        make([]*eventView, 0),
+		CalendarURL: template.URL(fmt.Sprintf("%s/calendar.ics", strings.Replace(baseURL, "https://", "webcal://", 1))),
+	}
+
+	for _, e := range allEvents {
+		if e.IsUpcoming(now) {
+			view := app.newEventView(e)
+			view.TakesRSVPs = e.TakesRSVPs(now)
+			schedule.Upcoming = append(schedule.Upcoming, view)
+		}
+	}
+	for i := len(allEvents) - 1; i >= 0; i-- {
+		if !allEvents[i].IsUpcoming(now) {
+			schedule.Past = append(schedule.Past, app.newEventView(allEvents[i]))
+		}
+	}
+
+	return schedule
+}
+
+func (app *application) newEventView(e *events.Event) *eventView {
+	view := &eventView{Event: e, Traditions: make([]*traditions.Tradition, 0, len(e.Traditions))}
+	for _, id := range e.Traditions {
+		// traditions are checked on load, so they can only be missing if they've since been removed
+		if t, err := app.traditionService.ByName(id); err == nil {
+			view.Traditions = append(view.Traditions, t)
+		}
+	}
+	return view
+}
+
+// withRSVPs hands out the event's seats, if it takes RSVPs.
+func (app *application) withRSVPs(view *eventView) (*eventView, error) {
+	if view.Event.RSVP == nil {
+		return view, nil
+	}
+
+	rsvps, err := app.rsvpService.ByEvent(view.Event.ID)
+	if err != nil {
+		return view, err
+	}
+	view.RSVPs, view.Seats = rsvp.Assign(rsvps, view.Event.RSVP.Capacity)
+	return view, nil
+}
+
+// settleRSVPs seats as many of every event's waitlist as fit, as an event's capacity can change between deploys.
+func (app *application) settleRSVPs() error {
+	allEvents, err := app.eventService.All()
+	if err != nil {
+		return err
+	}
+
+	for _, e := range allEvents {
+		if e.RSVP == nil {
+			continue
+		}
+		seated, err := app.rsvpService.Settle(e.ID, e.RSVP.Capacity)
+		if err != nil {
+			return fmt.Errorf("failed to seat the RSVPs to `%s`: %w", e.ID, err)
+		}
+		for _, r := range seated {
+			app.logger.Info(fmt.Sprintf("Seated RSVP `%s` to `%s` from the waitlist", r.ID, e.ID))
+		}
+	}
+	return nil
+}
+
+// newCalendar builds the iCalendar feed of every event.
+func (app *application) newCalendar(allEvents []*events.Event, stamp time.Time) *ical.Calendar {
+	calendar := &ical.Calendar{
+		ProductID:       "-//Purdoobahs//purdoobahs.com//EN",
+		Name:            "Purdoobahs",
+		Description:     "Game days and other events of the Purdue All-American Marching Band's toobah section",
+		RefreshInterval: calendarRefreshInterval,
+		Events:          make([]*ical.Event, 0, len(allEvents)),
+	}
+
+	for _, e := range allEvents {
+		view := app.newEventView(e)
+
+		// the description repeats everything that doesn't have a property of its own
+		description := make([]string, 0)
+		if e.Opponent != "" {
+			description = append(description, fmt.Sprintf("Opponent: %s", e.Opponent))
+		}
+		if e.CallTime != "" {
+			description = append(description, fmt.Sprintf("Toobah Call Time: %s", e.Start.Format("3:04 PM MST")))
+		}
+		if e.Description != "" {
+			description = append(description, e.Description)
+		}
+		categories := make([]string, 0, len(view.Traditions))
+		for _, t := range view.Traditions {
+			categories = append(categories, t.Name)
+		}
+		if len(categories) > 0 {
+			description = append(description, fmt.Sprintf("Traditions: %s", strings.Join(categories, ", ")))
+		}
+
+		event := &ical.Event{
+			UID:         fmt.Sprintf("%s@purdoobahs.com", e.ID),
+			Stamp:       stamp,
+			Summary:     e.Name,
+			Description: strings.Join(description, "\n"),
+			Location:    e.Location,
+			URL:         fmt.Sprintf("%s/events/%s", baseURL, e.ID),
+			Categories:  categories,
+			Start:       e.Start,
+			AllDay:      e.AllDay,
+		}
+		if e.End != nil {
+			event.End = *e.End
+		}
+		calendar.Events = append(calendar.Events, event)
+	}
+
+	return calendar
+}
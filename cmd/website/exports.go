@@ -0,0 +1,99 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/purdoobahs/purdoobahs.com/internal/apierror"
+	"github.com/purdoobahs/purdoobahs.com/internal/cachecontrol"
+	"github.com/purdoobahs/purdoobahs.com/internal/contentnegotiation"
+	"github.com/purdoobahs/purdoobahs.com/internal/export"
+	"github.com/purdoobahs/purdoobahs.com/internal/httpheader"
+	"github.com/purdoobahs/purdoobahs.com/internal/mimetype"
+	"github.com/purdoobahs/purdoobahs.com/internal/purdoobahs"
+)
+
+// purdoobahOffers are the representations of a single Purdoobah.
+var purdoobahOffers = []contentnegotiation.Offer{
+	{Format: "json", MimeType: mimetype.Json.String()},
+}
+
+// purdoobahListOffers are the representations of every API endpoint that returns a list of Purdoobahs.
+// JSON comes first, so that it remains the default.
+//
+// vCards aren't offered yet: export.VCard only writes Purdoobahs who opted in with `personal.share_contact`, and no
+// asset file does, so every export would be empty.
+var purdoobahListOffers = []contentnegotiation.Offer{
+	{Format: "json", MimeType: mimetype.Json.String()},
+	{Format: "csv", MimeType: mimetype.Csv.String()},
+	{Format: "ndjson", MimeType: mimetype.Ndjson.String()},
+}
+
+// fileExtensions maps each export format to the extension of its download.
+var fileExtensions = map[string]string{
+	"csv":    "csv",
+	"ndjson": "ndjson",
+}
+
+// writePurdoobahList sends out a list of Purdoobahs in whichever format the client negotiated.
+//
+// Everything but JSON is sent as an attachment named after filename (sans extension).
+func (app *application) writePurdoobahList(w http.ResponseWriter, r *http.Request, list []*purdoobahs.Purdoobah, filename string) {
+	// the response differs by Accept header
+	cachecontrol.Vary(w, httpheader.Accept.String())
+
+	offer, ok := app.negotiateAPI(w, r, purdoobahListOffers)
+	if !ok {
+		return
+	}
+
+	list = withoutPlaceholders(list)
+	if offer.Format == "json" {
+		app.writeJSON(w, r, http.StatusOK, list)
+		return
+	}
+
+	w.Header().Set(httpheader.ContentType.String(), fmt.Sprintf("%s; charset=utf-8", offer.MimeType))
+	w.Header().Set(
+		httpheader.ContentDisposition.String(),
+		fmt.Sprintf("attachment; filename=\"%s.%s\"", filename, fileExtensions[offer.Format]),
+	)
+
+	// headers have already been sent once writing begins, so errors can only be logged
+	var err error
+	switch offer.Format {
+	case "csv":
+		err = export.CSV(w, list)
+	case "ndjson":
+		err = export.NDJSON(w, list)
+	}
+	if err != nil {
+		app.logger.Error(err.Error())
+	}
+}
+
+// negotiateAPI returns the offer the client asked for, or sends out an error if they asked for a format that isn't
+// offered. API clients that don't name one of the offers get the first, so that they keep getting JSON.
+func (app *application) negotiateAPI(w http.ResponseWriter, r *http.Request, offers []contentnegotiation.Offer) (contentnegotiation.Offer, bool) {
+	offer, err := contentnegotiation.Negotiate(r, offers)
+	if errors.Is(err, contentnegotiation.ErrUnknownFormat) {
+		app.apiError(w, r, apierror.BadRequest(
+			fmt.Sprintf("format must be one of: %s", strings.Join(offerFormats(offers), ", ")),
+		).WithDetail("parameter", "format"))
+		return contentnegotiation.Offer{}, false
+	} else if err != nil {
+		app.serveError(w, r, err)
+		return contentnegotiation.Offer{}, false
+	}
+	return offer, true
+}
+
+func offerFormats(offers []contentnegotiation.Offer) []string {
+	formats := make([]string, 0, len(offers))
+	for _, offer := range offers {
+		formats = append(formats, offer.Format)
+	}
+	return formats
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/purdoobahs/purdoobahs.com/internal/gallery"
+	"github.com/purdoobahs/purdoobahs.com/internal/imaging"
+	"github.com/purdoobahs/purdoobahs.com/internal/purdoobahs"
+	"github.com/purdoobahs/purdoobahs.com/internal/sectionphotos"
+	"github.com/purdoobahs/purdoobahs.com/internal/traditions"
+)
+
+const (
+	// thumbnailDirectory is where thumbnails are generated to, relative to the static directory.
+	thumbnailDirectory = "/static/image/thumbnail"
+
+	// thumbnailSize is the largest a thumbnail's width and height can be.
+	thumbnailSize = 480
+
+	thumbnailQuality = 80
+)
+
+// galleryView is a gallery of photos, for rendering.
+type galleryView struct {
+	// Title says whose or what gallery it is (e.g. "2019 Section"); it's empty for the gallery of every photo.
+	Title string
+
+	// BackURL is the page the gallery belongs to.
+	BackURL string
+
+	Photos []*photoView
+}
+
+// photoView is a photo with who and what is in it looked up, for rendering.
+type photoView struct {
+	Photo      *gallery.Photo
+	Purdoobahs []*purdoobahs.Purdoobah
+	Traditions []*traditions.Tradition
+}
+
+func (app *application) newGalleryView(title, backURL string, photos []*gallery.Photo) *galleryView {
+	view := &galleryView{Title: title, BackURL: backURL, Photos: make([]*photoView, 0, len(photos))}
+
+	for _, p := range photos {
+		// tags are checked on load, so they can only be missing if they've since been removed
+		pv := &photoView{Photo: p}
+		for _, id := range p.Purdoobahs {
+			if purdoobah, err := app.purdoobahService.ByName(id); err == nil {
+				pv.Purdoobahs = append(pv.Purdoobahs, purdoobah)
+			}
+		}
+		for _, id := range p.Traditions {
+			if tradition, err := app.traditionService.ByName(id); err == nil {
+				pv.Traditions = append(pv.Traditions, tradition)
+			}
+		}
+		view.Photos = append(view.Photos, pv)
+	}
+
+	return view
+}
+
+// sectionPhotoView is a section photo with its tagged Purdoobahs looked up, for rendering as an image map.
+type sectionPhotoView struct {
+	Photo *sectionphotos.SectionPhoto
+	Tags  []*sectionPhotoTag
+}
+
+type sectionPhotoTag struct {
+	Tag       *sectionphotos.Tag
+	Purdoobah *purdoobahs.Purdoobah
+}
+
+func (app *application) newSectionPhotoView(sp *sectionphotos.SectionPhoto) *sectionPhotoView {
+	view := &sectionPhotoView{Photo: sp, Tags: make([]*sectionPhotoTag, 0, len(sp.Tags))}
+	for _, t := range sp.Tags {
+		// tags are checked on load, so they can only be missing if they've since been removed
+		if purdoobah, err := app.purdoobahService.ByName(t.Purdoobah); err == nil {
+			view.Tags = append(view.Tags, &sectionPhotoTag{Tag: t, Purdoobah: purdoobah})
+		}
+	}
+	return view
+}
+
+// generateThumbnail scales the photo down to a JPEG thumbnail, and adds it to the CacheBuster.
+func (app *application) generateThumbnail(p *gallery.Photo) error {
+	img, err := imaging.Decode(strings.TrimPrefix(p.Metadata.Image.File, "/"))
+	if err != nil {
+		return err
+	}
+	thumbnail := imaging.Fit(img, thumbnailSize, thumbnailSize)
+
+	err = os.MkdirAll(strings.TrimPrefix(thumbnailDirectory, "/"), 0755)
+	if err != nil {
+		return err
+	}
+
+	thumbnailFilepath := fmt.Sprintf("%s/%s.jpg", thumbnailDirectory, p.ID)
+	err = imaging.WriteJPEG(strings.TrimPrefix(thumbnailFilepath, "/"), thumbnail, thumbnailQuality)
+	if err != nil {
+		return err
+	}
+
+	// add to CacheBuster
+	err = app.cacheBuster.Add(thumbnailFilepath)
+	if err != nil {
+		return err
+	}
+
+	p.Metadata.Thumbnail.File = app.cacheBuster.Get(thumbnailFilepath)
+	p.Metadata.Thumbnail.Width = thumbnail.Bounds().Dx()
+	p.Metadata.Thumbnail.Height = thumbnail.Bounds().Dy()
+	return nil
+}
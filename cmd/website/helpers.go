@@ -6,47 +6,50 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
 
+	"github.com/goddtriffin/fontawesome"
+	"github.com/purdoobahs/purdoobahs.com/internal/achievements"
+	"github.com/purdoobahs/purdoobahs.com/internal/cravers"
+	"github.com/purdoobahs/purdoobahs.com/internal/events"
+	"github.com/purdoobahs/purdoobahs.com/internal/gallery"
+	"github.com/purdoobahs/purdoobahs.com/internal/imaging"
 	"github.com/purdoobahs/purdoobahs.com/internal/purdoobahs"
+	"github.com/purdoobahs/purdoobahs.com/internal/sectionphotos"
 	"github.com/purdoobahs/purdoobahs.com/internal/traditions"
 )
 
 func (app *application) loadPurdoobahs() (map[string]*purdoobahs.Purdoobah, error) {
 	allPurdoobahs := make(map[string]*purdoobahs.Purdoobah)
 
-	// read in the Purdoobah JSON Schema
-	filepaths, err := app.walkMatch("./assets/purdoobahs/", `*.json`)
-	if err != nil {
-		app.logger.Error("failed to load Purdoobah JSON filepaths")
-		return allPurdoobahs, err
-	}
+	err := loadAssets(app, "./assets/purdoobahs/", "Purdoobah", func(id string, p *purdoobahs.Purdoobah) error {
+		p.ID = id
 
-	// loop through each file
-	for _, path := range filepaths {
-		// ignore _purdoobah.schema.json and _template.json
-		if strings.Contains(path, "_") {
-			continue
+		// make sure its seasons line up with the years it marched
+		err := p.ValidateSeasons()
+		if err != nil {
+			return err
 		}
 
-		// read in the Purdoobah JSON document
-		b, err := ioutil.ReadFile(path)
+		// make sure their leadership roles were held while they were marching
+		err = p.ValidateLeadership()
 		if err != nil {
-			app.logger.Error("failed to read in Purdoobah JSON file")
-			return allPurdoobahs, err
+			return err
 		}
 
-		// marshal it from JSON to struct
-		var p purdoobahs.Purdoobah
-		err = json.Unmarshal(b, &p)
-		if err != nil {
-			app.logger.Error("failed to unmarshal Purdoobah JSON")
-			return allPurdoobahs, err
+		// derive year in school
+		p.Education.EffectiveYear = p.DerivedYear()
+
+		// derive the achievements implied by their leadership roles
+		p.Achievements.StudentLeader = p.StudentLeaderYears()
+		if p.ChairedCommittee(purdoobahs.BottomFeederCommittee) {
+			p.Achievements.BottomFeederCommittee = true
 		}
 
-		// generate ID (the purdoobah file name)
-		id := strings.ReplaceAll(filepath.Base(path), ".json", "")
-		p.ID = id
+		// only keep what they've agreed to share about their post-grad life
+		p.RedactAlumni()
 
 		// generate image location
 		const baseImagePath = "/static/image/purdoobah"
@@ -58,64 +61,333 @@ func (app *application) loadPurdoobahs() (map[string]*purdoobahs.Purdoobah, erro
 		}
 		p.Metadata.Image.Alt = fmt.Sprintf("%s's Profile Picture", p.Name)
 
+		// describe the image, so that pages can make room for it and show a placeholder while it loads
+		description, err := app.describeImage(p.Metadata.Image.File)
+		if err != nil {
+			return fmt.Errorf("Purdoobah `%s`: %w", id, err)
+		}
+		p.Metadata.Image.Width = description.Width
+		p.Metadata.Image.Height = description.Height
+		p.Metadata.Image.DominantColor = description.DominantColor
+		p.Metadata.Image.Placeholder = description.Placeholder
+
 		// add it to container of all purdoobahs
-		allPurdoobahs[id] = &p
+		allPurdoobahs[id] = p
+		return nil
+	})
+	return allPurdoobahs, err
+}
+
+// reportYearDisagreements logs every Purdoobah whose stored year in school disagrees with the one derived from the
+// years they marched, so that their asset file can be corrected (or the stored year removed).
+func (app *application) reportYearDisagreements(allPurdoobahs map[string]*purdoobahs.Purdoobah) {
+	ids := make([]string, 0, len(allPurdoobahs))
+	for id := range allPurdoobahs {
+		ids = append(ids, id)
 	}
+	sort.Strings(ids)
+
+	disagreements := 0
+	for _, id := range ids {
+		p := allPurdoobahs[id]
+		if p.Education.Year == "" || p.Education.Year == p.Education.EffectiveYear {
+			continue
+		}
 
-	return allPurdoobahs, nil
+		disagreements++
+		app.logger.Error(fmt.Sprintf(
+			"Purdoobah `%s` is stored as `%s`, but is `%s` according to the years they marched",
+			id, p.Education.Year, p.Education.EffectiveYear,
+		))
+	}
+
+	if disagreements > 0 {
+		app.logger.Info(fmt.Sprintf("%d Purdoobahs' stored year in school disagrees with their effective year", disagreements))
+	}
 }
 
 func (app *application) loadTraditions() (map[string]*traditions.Tradition, error) {
 	allTraditions := make(map[string]*traditions.Tradition)
 
-	// read in the Tradition JSON Schema
-	filepaths, err := app.walkMatch("./assets/traditions/", `*.json`)
+	err := loadAssets(app, "./assets/traditions/", "Tradition", func(id string, t *traditions.Tradition) error {
+		t.ID = id
+
+		// generate image location
+		const baseImagePath = "/static/image/tradition"
+		if app.doesTraditionHavePicture(id) {
+			t.Metadata.Image.File = app.cacheBuster.Get(fmt.Sprintf("%s/%s.webp", baseImagePath, id))
+		} else {
+			id := "_unknown"
+			t.Metadata.Image.File = app.cacheBuster.Get(fmt.Sprintf("%s/%s.webp", baseImagePath, id))
+		}
+		t.Metadata.Image.Alt = fmt.Sprintf("%s", t.Name)
+
+		// describe the image, so that pages can make room for it and show a placeholder while it loads
+		description, err := app.describeImage(t.Metadata.Image.File)
+		if err != nil {
+			return fmt.Errorf("Tradition `%s`: %w", id, err)
+		}
+		t.Metadata.Image.Width = description.Width
+		t.Metadata.Image.Height = description.Height
+		t.Metadata.Image.DominantColor = description.DominantColor
+		t.Metadata.Image.Placeholder = description.Placeholder
+
+		// add it to container of all traditions
+		allTraditions[id] = t
+		return nil
+	})
+	return allTraditions, err
+}
+
+func (app *application) loadCravers() (map[string]*cravers.Craver, error) {
+	allCravers := make(map[string]*cravers.Craver)
+
+	err := loadAssets(app, "./assets/cravers/", "Craver", func(id string, c *cravers.Craver) error {
+		c.ID = id
+
+		// an inducted Purdoobah has to exist
+		var inductee *purdoobahs.Purdoobah
+		if c.Purdoobah != "" {
+			var err error
+			inductee, err = app.purdoobahService.ByName(c.Purdoobah)
+			if err != nil {
+				return fmt.Errorf("Craver `%s`: %w", id, err)
+			}
+		}
+
+		// generate image location, falling back to the inducted Purdoobah's profile picture
+		switch {
+		case c.Photo != "" && app.cacheBuster.Get(c.Photo) != "":
+			c.Metadata.Image.File = app.cacheBuster.Get(c.Photo)
+		case inductee != nil:
+			c.Metadata.Image.File = inductee.Metadata.Image.File
+		default:
+			app.logger.Error(fmt.Sprintf("craver doesn't have an image: `%s`", id))
+			c.Metadata.Image.File = app.cacheBuster.Get("/static/image/socials/cravers-hall-of-fame.webp")
+		}
+		c.Metadata.Image.Alt = fmt.Sprintf("%s, inducted in %d", c.Name, c.Year)
+
+		// describe the image, so that pages can make room for it and show a placeholder while it loads
+		description, err := app.describeImage(c.Metadata.Image.File)
+		if err != nil {
+			return fmt.Errorf("Craver `%s`: %w", id, err)
+		}
+		c.Metadata.Image.Width = description.Width
+		c.Metadata.Image.Height = description.Height
+		c.Metadata.Image.DominantColor = description.DominantColor
+		c.Metadata.Image.Placeholder = description.Placeholder
+
+		// add it to container of all cravers
+		allCravers[id] = c
+		return nil
+	})
+	return allCravers, err
+}
+
+func (app *application) loadAchievements() (map[string]*achievements.Achievement, error) {
+	allAchievements := make(map[string]*achievements.Achievement)
+
+	// every badge's icon has to exist
+	fa, err := fontawesome.New("./assets/icons.json")
+	if err != nil {
+		return allAchievements, err
+	}
+
+	err = loadAssets(app, "./assets/achievements/", "Achievement", func(id string, a *achievements.Achievement) error {
+		a.ID = id
+
+		_, err = fa.SVG(a.Icon.Prefix, a.Icon.Name)
+		if err != nil {
+			return fmt.Errorf("Achievement `%s`: %w", id, err)
+		}
+
+		// add it to container of all achievements
+		allAchievements[id] = a
+		return nil
+	})
+	if err != nil {
+		return allAchievements, err
+	}
+
+	// every honor a Purdoobah holds has to be registered
+	allPurdoobahs, err := app.purdoobahService.All()
+	if err != nil {
+		return allAchievements, err
+	}
+	err = achievements.Validate(allAchievements, allPurdoobahs)
+	if err != nil {
+		return allAchievements, err
+	}
+
+	return allAchievements, nil
+}
+
+func (app *application) loadEvents() (map[string]*events.Event, error) {
+	allEvents := make(map[string]*events.Event)
+
+	err := loadAssets(app, "./assets/events/", "Event", func(id string, e *events.Event) error {
+		e.ID = id
+
+		// generate when it starts and ends
+		err := e.Schedule()
+		if err != nil {
+			return err
+		}
+
+		// every tagged tradition has to exist
+		for _, tradition := range e.Traditions {
+			_, err = app.traditionService.ByName(tradition)
+			if err != nil {
+				return fmt.Errorf("Event `%s`: %w", id, err)
+			}
+		}
+
+		// add it to container of all events
+		allEvents[id] = e
+		return nil
+	})
+	return allEvents, err
+}
+
+func (app *application) loadPhotos() (map[string]*gallery.Photo, error) {
+	allPhotos := make(map[string]*gallery.Photo)
+
+	err := loadAssets(app, "./assets/photos/", "Photo", func(id string, p *gallery.Photo) error {
+		p.ID = id
+
+		// its section has to exist
+		_, err := app.purdoobahService.SectionByYear(p.Year)
+		if err != nil {
+			return fmt.Errorf("Photo `%s`: %w", id, err)
+		}
+
+		// every tagged Purdoobah and tradition has to exist
+		for _, purdoobah := range p.Purdoobahs {
+			_, err = app.purdoobahService.ByName(purdoobah)
+			if err != nil {
+				return fmt.Errorf("Photo `%s`: %w", id, err)
+			}
+		}
+		for _, tradition := range p.Traditions {
+			_, err = app.traditionService.ByName(tradition)
+			if err != nil {
+				return fmt.Errorf("Photo `%s`: %w", id, err)
+			}
+		}
+
+		if p.License.URL == "" {
+			p.License = gallery.DefaultLicense
+		}
+
+		// generate image location; the gallery directory isn't walked by the CacheBuster, as it may not exist until the
+		// first photo is added
+		if app.cacheBuster.Get(p.File) == "" {
+			err = app.cacheBuster.Add(p.File)
+			if err != nil {
+				return fmt.Errorf("Photo `%s`: %w", id, err)
+			}
+		}
+		p.Metadata.Image.File = app.cacheBuster.Get(p.File)
+		p.Metadata.Image.Alt = p.Alt
+		if p.Metadata.Image.Alt == "" {
+			p.Metadata.Image.Alt = p.Caption
+		}
+
+		// generate its thumbnail
+		err = app.generateThumbnail(p)
+		if err != nil {
+			return fmt.Errorf("Photo `%s`: %w", id, err)
+		}
+
+		// add it to container of all photos
+		allPhotos[id] = p
+		return nil
+	})
+	return allPhotos, err
+}
+
+func (app *application) loadSectionPhotos() (map[int]*sectionphotos.SectionPhoto, error) {
+	allSectionPhotos := make(map[int]*sectionphotos.SectionPhoto)
+
+	err := loadAssets(app, "./assets/section-photos/", "Section Photo", func(id string, sp *sectionphotos.SectionPhoto) error {
+		// generate year (the section photo file name)
+		year, err := strconv.Atoi(id)
+		if err != nil {
+			return fmt.Errorf("section photo tags have to be named after the section's year: `%s.json`", id)
+		}
+		sp.Year = year
+
+		// the section has to have a photo to tag
+		if !app.doesSectionHaveSocialImage(year) {
+			return fmt.Errorf("%d section photo tags don't have a photo to tag", year)
+		}
+		photo := app.cacheBuster.Get(fmt.Sprintf("/static/image/section/%d.webp", year))
+		sp.Width, sp.Height, err = imaging.Size(strings.TrimPrefix(photo, "/"))
+		if err != nil {
+			return err
+		}
+
+		// every tagged Purdoobah has to exist, and have marched that year
+		for _, t := range sp.Tags {
+			purdoobah, err := app.purdoobahService.ByName(t.Purdoobah)
+			if err != nil {
+				return fmt.Errorf("%d section photo: %w", year, err)
+			}
+			if !purdoobah.MarchedDuringYear(year) {
+				return fmt.Errorf("%d section photo tags Purdoobah `%s`, who didn't march that year", year, t.Purdoobah)
+			}
+		}
+
+		// and every tag has to be inside the photo
+		err = sp.Validate()
+		if err != nil {
+			return err
+		}
+
+		// add it to container of all section photos
+		allSectionPhotos[year] = sp
+		return nil
+	})
+	return allSectionPhotos, err
+}
+
+// loadAssets reads in every asset file in dir, and hands each one to load along with its ID (its file name). Files with
+// an underscore in their name (e.g. the JSON Schema and _template.json) are skipped.
+func loadAssets[T any](app *application, dir string, kind string, load func(id string, asset *T) error) error {
+	filepaths, err := app.walkMatch(dir, `*.json`)
 	if err != nil {
-		app.logger.Error("failed to load Tradition JSON filepaths")
-		return allTraditions, err
+		app.logger.Error(fmt.Sprintf("failed to load %s JSON filepaths", kind))
+		return err
 	}
 
 	// loop through each file
 	for _, path := range filepaths {
-		// ignore _tradition.schema.json and _template.json
-		if strings.Contains(path, "_") {
+		if strings.Contains(filepath.Base(path), "_") {
 			continue
 		}
 
-		// read in the Tradition JSON document
+		// read in the JSON document
 		b, err := ioutil.ReadFile(path)
 		if err != nil {
-			app.logger.Error("failed to read in Tradition JSON file")
-			return allTraditions, err
+			app.logger.Error(fmt.Sprintf("failed to read in %s JSON file", kind))
+			return err
 		}
 
 		// marshal it from JSON to struct
-		var t traditions.Tradition
-		err = json.Unmarshal(b, &t)
+		var asset T
+		err = json.Unmarshal(b, &asset)
 		if err != nil {
-			app.logger.Error("failed to unmarshal Tradition JSON")
-			return allTraditions, err
+			app.logger.Error(fmt.Sprintf("failed to unmarshal %s JSON", kind))
+			return fmt.Errorf("%w: `%s`", err, path)
 		}
 
-		// generate ID (the tradition file name)
-		id := strings.ReplaceAll(filepath.Base(path), ".json", "")
-		t.ID = id
-
-		// generate image location
-		const baseImagePath = "/static/image/tradition"
-		if app.doesTraditionHavePicture(id) {
-			t.Metadata.Image.File = app.cacheBuster.Get(fmt.Sprintf("%s/%s.webp", baseImagePath, id))
-		} else {
-			id := "_unknown"
-			t.Metadata.Image.File = app.cacheBuster.Get(fmt.Sprintf("%s/%s.webp", baseImagePath, id))
+		err = load(strings.TrimSuffix(filepath.Base(path), ".json"), &asset)
+		if err != nil {
+			return err
 		}
-		t.Metadata.Image.Alt = fmt.Sprintf("%s", t.Name)
-
-		// add it to container of all purdoobahs
-		allTraditions[id] = &t
 	}
 
-	return allTraditions, nil
+	return nil
 }
 
 func (app *application) walkMatch(root, pattern string) ([]string, error) {
@@ -0,0 +1,175 @@
+package main
+
+import (
+	"fmt"
+	"html/template"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/purdoobahs/purdoobahs.com/internal/gazetteer"
+	"github.com/purdoobahs/purdoobahs.com/internal/geojson"
+	"github.com/purdoobahs/purdoobahs.com/internal/purdoobahs"
+	"github.com/purdoobahs/purdoobahs.com/internal/svgmap"
+)
+
+// hometown is a place that one or more Purdoobahs call home.
+type hometown struct {
+	Place      *gazetteer.Place
+	Purdoobahs []*purdoobahs.Purdoobah
+}
+
+// hometownProperties are the properties of a hometown's GeoJSON feature.
+type hometownProperties struct {
+	City       string   `json:"city"`
+	State      string   `json:"state"`
+	Count      int      `json:"count"`
+	Purdoobahs []string `json:"purdoobahs"`
+}
+
+// resolveHometowns locates every Purdoobah's hometown.
+//
+// Hometowns that can't be found are reported so that they can be fixed, but aren't fatal; those Purdoobahs are just
+// left off of the map. Hometowns we don't know in the first place aren't reported.
+func (app *application) resolveHometowns() error {
+	g, err := gazetteer.NewGazetteer()
+	if err != nil {
+		return err
+	}
+
+	allPurdoobahs, err := app.purdoobahService.All()
+	if err != nil {
+		return err
+	}
+
+	app.hometowns = make(map[string]*gazetteer.Place)
+	known := 0
+	for _, p := range allPurdoobahs {
+		city := strings.TrimSpace(p.Hometown.City)
+		state := strings.TrimSpace(p.Hometown.State)
+		if isUnknownHometown(city) || isUnknownHometown(state) {
+			continue
+		}
+		known++
+
+		place, ok := g.Lookup(city, state)
+		if !ok {
+			app.logger.Error(fmt.Sprintf("Unresolvable hometown for Purdoobah `%s`: `%s, %s`", p.ID, city, state))
+			continue
+		}
+		app.hometowns[p.ID] = place
+	}
+
+	app.logger.Info(fmt.Sprintf("Resolved %d of %d known hometowns", len(app.hometowns), known))
+	return nil
+}
+
+func isUnknownHometown(s string) bool {
+	return s == "" || strings.EqualFold(s, "unknown")
+}
+
+// mapPurdoobahs returns the Purdoobahs to put on the map: the section of the `year` query parameter, or everyone if
+// it isn't set. The year is 0 when it's everyone.
+func (app *application) mapPurdoobahs(yearAsString string) ([]*purdoobahs.Purdoobah, int, error) {
+	if yearAsString == "" {
+		allPurdoobahs, err := app.purdoobahService.All()
+		return allPurdoobahs, 0, err
+	}
+
+	year, err := strconv.Atoi(yearAsString)
+	if err != nil || year == 0 {
+		return nil, 0, fmt.Errorf("%w: `%s`", purdoobahs.ErrSectionNotFound, yearAsString)
+	}
+
+	section, err := app.purdoobahService.SectionByYear(year)
+	return section, year, err
+}
+
+// groupHometowns groups Purdoobahs by their resolved hometown, most common first.
+func (app *application) groupHometowns(members []*purdoobahs.Purdoobah) []*hometown {
+	byPlace := make(map[*gazetteer.Place]*hometown)
+	hometowns := make([]*hometown, 0)
+	for _, p := range members {
+		place, ok := app.hometowns[p.ID]
+		if !ok {
+			continue
+		}
+
+		h, ok := byPlace[place]
+		if !ok {
+			h = &hometown{Place: place}
+			byPlace[place] = h
+			hometowns = append(hometowns, h)
+		}
+		h.Purdoobahs = append(h.Purdoobahs, p)
+	}
+
+	for _, h := range hometowns {
+		sort.Sort(purdoobahs.ByName(h.Purdoobahs))
+	}
+	sort.SliceStable(hometowns, func(i, j int) bool {
+		if len(hometowns[i].Purdoobahs) != len(hometowns[j].Purdoobahs) {
+			return len(hometowns[i].Purdoobahs) > len(hometowns[j].Purdoobahs)
+		}
+		if hometowns[i].Place.State != hometowns[j].Place.State {
+			return hometowns[i].Place.State < hometowns[j].Place.State
+		}
+		return hometowns[i].Place.Name < hometowns[j].Place.Name
+	})
+
+	return hometowns
+}
+
+// hometownFeatures turns hometowns into a GeoJSON feature collection.
+func hometownFeatures(hometowns []*hometown) *geojson.FeatureCollection {
+	features := make([]*geojson.Feature, 0, len(hometowns))
+	for _, h := range hometowns {
+		ids := make([]string, 0, len(h.Purdoobahs))
+		for _, p := range h.Purdoobahs {
+			ids = append(ids, p.ID)
+		}
+
+		features = append(features, geojson.NewPointFeature(h.Place.Lat, h.Place.Lng, &hometownProperties{
+			City:       h.Place.Name,
+			State:      h.Place.State,
+			Count:      len(h.Purdoobahs),
+			Purdoobahs: ids,
+		}))
+	}
+	return geojson.NewFeatureCollection(features)
+}
+
+// hometownMaps renders the map page's maps: the whole country, and a closer look at Indiana.
+func hometownMaps(hometowns []*hometown, sectionName string) map[string]template.HTML {
+	dots := make([]svgmap.Dot, 0, len(hometowns))
+	for _, h := range hometowns {
+		names := make([]string, 0, len(h.Purdoobahs))
+		for _, p := range h.Purdoobahs {
+			names = append(names, p.Name)
+		}
+
+		dots = append(dots, svgmap.Dot{
+			Lat:   h.Place.Lat,
+			Lng:   h.Place.Lng,
+			Count: len(h.Purdoobahs),
+			Label: fmt.Sprintf("%s, %s: %s", h.Place.Name, h.Place.State, strings.Join(names, ", ")),
+		})
+	}
+
+	return map[string]template.HTML{
+		"unitedStates": (&svgmap.Map{
+			Title:       "Hometowns",
+			Description: fmt.Sprintf("The hometowns of %s across the United States", sectionName),
+			Region:      svgmap.ContiguousUS,
+			Dots:        dots,
+			Width:       900,
+		}).SVG(),
+		"indiana": (&svgmap.Map{
+			Title:       "Indiana Hometowns",
+			Description: fmt.Sprintf("The hometowns of %s within Indiana", sectionName),
+			Region:      svgmap.Indiana,
+			Dots:        dots,
+			Width:       400,
+		}).SVG(),
+	}
+}
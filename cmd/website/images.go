@@ -0,0 +1,115 @@
+package main
+
+import (
+	"fmt"
+	"html"
+	"html/template"
+	"net/url"
+	"strings"
+
+	"github.com/purdoobahs/purdoobahs.com/internal/imaging"
+	"github.com/purdoobahs/purdoobahs.com/internal/purdoobahs"
+)
+
+// imageDescription is what's worked out about an image at startup, so that pages can make room for it and show a
+// placeholder while it loads.
+type imageDescription struct {
+	Width         int
+	Height        int
+	DominantColor string
+	Placeholder   string
+}
+
+// describeImage decodes the cache-busted image at the given path and describes it. Descriptions are remembered, as many
+// profiles share the same image.
+func (app *application) describeImage(path string) (*imageDescription, error) {
+	if description, ok := app.imageDescriptions[path]; ok {
+		return description, nil
+	}
+
+	if path == "" {
+		return nil, fmt.Errorf("image not found in CacheBuster")
+	}
+
+	img, err := imaging.Decode(strings.TrimPrefix(path, "/"))
+	if err != nil {
+		return nil, err
+	}
+
+	description := &imageDescription{
+		Width:         img.Bounds().Dx(),
+		Height:        img.Bounds().Dy(),
+		DominantColor: imaging.DominantColor(img),
+	}
+
+	// placeholders are shown behind the image, so they'd show through any transparent parts of it
+	if imaging.IsOpaque(img) {
+		description.Placeholder, err = imaging.Placeholder(img)
+		if err != nil {
+			return nil, err
+		}
+	}
+	app.imageDescriptions[path] = description
+	return description, nil
+}
+
+// imageURL is the URL of the cache-busted image at the given path, resized to the given width in its default format.
+func imageURL(path string, width int) string {
+	query := url.Values{}
+	query.Set("src", path)
+	query.Set("w", fmt.Sprint(width))
+	return fmt.Sprintf("/image?%s", query.Encode())
+}
+
+// placeholderURL marks an image placeholder as safe to use as a URL, which html/template wouldn't otherwise allow, as
+// it's a data URL. Anything that isn't a placeholder is left for html/template to escape.
+func placeholderURL(placeholder string) interface{} {
+	if strings.HasPrefix(placeholder, "data:image/png;base64,") {
+		return template.URL(placeholder)
+	}
+	return placeholder
+}
+
+// srcset returns the srcset and sizes attributes of an <img> for the cache-busted image at the given path, offering
+// every width variant narrower than the image itself alongside the image at its full width.
+//
+// Nothing is returned for images the Resizer can't serve, so the <img> just falls back to its src.
+func (app *application) srcset(path, sizes string) template.HTMLAttr {
+	hash, ok := app.cacheBuster.Hash(path)
+	if !ok || hash == "" {
+		return ""
+	}
+
+	imageWidth, _, err := app.resizer.Size(strings.TrimPrefix(path, "/"))
+	if err != nil {
+		app.logger.Error(err.Error())
+		return ""
+	}
+
+	candidates := make([]string, 0, len(app.resizer.Widths)+1)
+	for _, width := range app.resizer.Widths {
+		if width >= imageWidth {
+			break
+		}
+		candidates = append(candidates, fmt.Sprintf("%s %dw", imageURL(path, width), width))
+	}
+	if len(candidates) == 0 {
+		return ""
+	}
+	candidates = append(candidates, fmt.Sprintf("%s %dw", path, imageWidth))
+
+	return template.HTMLAttr(fmt.Sprintf(
+		`srcset="%s" sizes="%s"`,
+		html.EscapeString(strings.Join(candidates, ", ")),
+		html.EscapeString(sizes),
+	))
+}
+
+// withoutPlaceholders copies the Purdoobahs without their image placeholders, which add up in lists.
+func withoutPlaceholders(ps []*purdoobahs.Purdoobah) []*purdoobahs.Purdoobah {
+	without := make([]*purdoobahs.Purdoobah, 0, len(ps))
+	for _, p := range ps {
+		without = append(without, p.WithoutPlaceholder())
+	}
+	return without
+}
@@ -6,21 +6,42 @@ import (
 	"html/template"
 	"net/http"
 	"os"
+	"path/filepath"
 	"strings"
 	"time"
 
+	"github.com/purdoobahs/purdoobahs.com/internal/achievements"
 	"github.com/purdoobahs/purdoobahs.com/internal/cachebuster"
 	"github.com/purdoobahs/purdoobahs.com/internal/cachecontrol"
+	"github.com/purdoobahs/purdoobahs.com/internal/cors"
+	"github.com/purdoobahs/purdoobahs.com/internal/cravers"
+	"github.com/purdoobahs/purdoobahs.com/internal/csrf"
+	"github.com/purdoobahs/purdoobahs.com/internal/events"
+	"github.com/purdoobahs/purdoobahs.com/internal/gallery"
+	"github.com/purdoobahs/purdoobahs.com/internal/gazetteer"
+	"github.com/purdoobahs/purdoobahs.com/internal/graphqlapi"
+	"github.com/purdoobahs/purdoobahs.com/internal/imageresize"
 	"github.com/purdoobahs/purdoobahs.com/internal/logger"
+	"github.com/purdoobahs/purdoobahs.com/internal/openapi"
+	"github.com/purdoobahs/purdoobahs.com/internal/ratelimit"
+	"github.com/purdoobahs/purdoobahs.com/internal/rsvp"
+	"github.com/purdoobahs/purdoobahs.com/internal/sectionphotos"
+	"github.com/purdoobahs/purdoobahs.com/internal/socialcard"
+	"github.com/purdoobahs/purdoobahs.com/internal/spamguard"
+	"github.com/purdoobahs/purdoobahs.com/internal/toobahsassins"
 	"github.com/purdoobahs/purdoobahs.com/internal/traditions"
+	"github.com/purdoobahs/purdoobahs.com/internal/trustedproxy"
 
 	"github.com/purdoobahs/purdoobahs.com/internal/inmemorydatabase"
+	"github.com/purdoobahs/purdoobahs.com/internal/jsondatabase"
+	"github.com/purdoobahs/purdoobahs.com/internal/jsonstore"
 
 	"github.com/purdoobahs/purdoobahs.com/internal/jsonschema"
 
 	"github.com/purdoobahs/purdoobahs.com/internal/purdoobahs"
 
 	"github.com/goddtriffin/helmet"
+	"github.com/gorilla/mux"
 )
 
 type environment int
@@ -37,25 +58,65 @@ type application struct {
 
 	helmet       *helmet.Helmet
 	cacheBuster  *cachebuster.CacheBuster
+	resizer      *imageresize.Resizer
+	socialCards  *socialcard.Renderer
 	cacheControl *cachecontrol.CacheControl
+	cors         *cors.Cors
+	trustedProxy *trustedproxy.TrustedProxy
+	rateLimiter  *ratelimit.RateLimiter
+	csrf         *csrf.CSRF
+	spamGuard    *spamguard.SpamGuard
 
-	purdoobahService purdoobahs.IPurdoobahService
-	traditionService traditions.ITraditionService
+	// apiKeys is the set of API keys which are rate limited by key instead of by client IP
+	apiKeys map[string]bool
+
+	// adminPassword unlocks the admin UI; the admin UI is disabled when it's empty
+	adminPassword string
+
+	purdoobahService    purdoobahs.IPurdoobahService
+	traditionService    traditions.ITraditionService
+	craverService       cravers.ICraverService
+	achievementService  achievements.IAchievementService
+	eventService        events.IEventService
+	photoService        gallery.IPhotoService
+	sectionPhotoService sectionphotos.ISectionPhotoService
+	rsvpService         rsvp.IRSVPService
+	gameService         toobahsassins.IGameService
+
+	// applicationService is the review queue of Cravers Hall of Fame applications
+	applicationService cravers.IApplicationService
+
+	graphQL *graphqlapi.GraphQL
+
+	// imageDescriptions are the sizes, dominant colors, and placeholders of images, by cache-busted path
+	imageDescriptions map[string]*imageDescription
+
+	// hometowns are the resolved locations of Purdoobahs' hometowns, by Purdoobah ID
+	hometowns map[string]*gazetteer.Place
 
 	httpClient *http.Client
+
+	router              *mux.Router
+	openAPIDocument     *openapi.Document
+	openAPIDocumentJSON []byte
 }
 
 func main() {
 	// initialize the application
 	app := &application{
-		logger:       logger.NewLogger(),
-		helmet:       createHelmet(),
-		cacheControl: createCacheControl(),
+		logger:            logger.NewLogger(),
+		helmet:            createHelmet(),
+		cacheControl:      createCacheControl(),
+		imageDescriptions: make(map[string]*imageDescription),
 	}
 
 	// parse environment variables
 	var addr string
 	var env string
+	var trustedProxies string
+	var apiKeys string
+	var corsAllowedOrigins string
+	var dataDir string
 	for _, e := range os.Environ() {
 		pair := strings.SplitN(e, "=", 2)
 
@@ -64,6 +125,16 @@ func main() {
 			addr = pair[1]
 		case "ENV":
 			env = pair[1]
+		case "TRUSTED_PROXIES":
+			trustedProxies = pair[1]
+		case "API_KEYS":
+			apiKeys = pair[1]
+		case "CORS_ALLOWED_ORIGINS":
+			corsAllowedOrigins = pair[1]
+		case "DATA_DIR":
+			dataDir = pair[1]
+		case "ADMIN_PASSWORD":
+			app.adminPassword = pair[1]
 		}
 	}
 
@@ -72,6 +143,11 @@ func main() {
 		addr = ":8080"
 	}
 
+	// set default data directory if it isn't set
+	if dataDir == "" {
+		dataDir = "./data"
+	}
+
 	// set environment
 	switch strings.ToLower(env) {
 	case "dev", "develop", "development":
@@ -84,6 +160,37 @@ func main() {
 		os.Exit(1)
 	}
 
+	// create the trusted proxy allowlist
+	trustedProxy, err := createTrustedProxy(trustedProxies)
+	if err != nil {
+		app.logger.Error(err.Error())
+		os.Exit(1)
+	}
+	app.trustedProxy = trustedProxy
+
+	// create CORS for the public API
+	app.cors = createCors(corsAllowedOrigins)
+
+	// create CSRF protection for forms
+	app.csrf = createCSRF(app.env == production, http.HandlerFunc(app.csrfFailure))
+
+	// create spam protection for public forms
+	spamGuard, err := createSpamGuard()
+	if err != nil {
+		app.logger.Error(err.Error())
+		os.Exit(1)
+	}
+	app.spamGuard = spamGuard
+
+	// create the rate limiter
+	app.apiKeys = make(map[string]bool)
+	for _, apiKey := range strings.Split(apiKeys, ",") {
+		if apiKey = strings.TrimSpace(apiKey); apiKey != "" {
+			app.apiKeys[apiKey] = true
+		}
+	}
+	app.rateLimiter = createRateLimiter(app.rateLimitKey, http.HandlerFunc(app.tooManyRequests))
+
 	// generate CacheBuster
 	cacheBuster, err := cachebuster.NewCacheBuster(
 		"static",
@@ -107,6 +214,22 @@ func main() {
 	}
 	app.cacheBuster = cacheBuster
 
+	// create the Resizer, which caches resized variants of the static images in the data directory
+	resizer, err := imageresize.NewResizer(filepath.Join(dataDir, "image-cache"))
+	if err != nil {
+		app.logger.Error(err.Error())
+		os.Exit(1)
+	}
+	app.resizer = resizer
+
+	// create the social card Renderer, which caches the cards it draws in the data directory
+	socialCards, err := socialcard.NewRenderer(filepath.Join(dataDir, "social-cards"))
+	if err != nil {
+		app.logger.Error(err.Error())
+		os.Exit(1)
+	}
+	app.socialCards = socialCards
+
 	// validate all Purdoobah JSON schema files
 	invalidFiles, err := jsonschema.ValidateJsonSchema(app.logger)
 	if err != nil {
@@ -124,6 +247,7 @@ func main() {
 		app.logger.Error(err.Error())
 		os.Exit(1)
 	}
+	app.reportYearDisagreements(allPurdoobahs)
 	app.purdoobahService = inmemorydatabase.NewPurdoobahService(allPurdoobahs)
 
 	// load Tradition files into Tradition service
@@ -134,6 +258,139 @@ func main() {
 	}
 	app.traditionService = inmemorydatabase.NewTraditionService(allTraditions)
 
+	// load Craver files into Craver service
+	allCravers, err := app.loadCravers()
+	if err != nil {
+		app.logger.Error(err.Error())
+		os.Exit(1)
+	}
+	app.craverService = inmemorydatabase.NewCraverService(allCravers)
+
+	// load Achievement files into Achievement service
+	allAchievements, err := app.loadAchievements()
+	if err != nil {
+		app.logger.Error(err.Error())
+		os.Exit(1)
+	}
+	app.achievementService = inmemorydatabase.NewAchievementService(allAchievements)
+
+	// load Event files into Event service
+	allEvents, err := app.loadEvents()
+	if err != nil {
+		app.logger.Error(err.Error())
+		os.Exit(1)
+	}
+	app.eventService = inmemorydatabase.NewEventService(allEvents)
+
+	// load Photo files into Photo service, generating their thumbnails
+	allPhotos, err := app.loadPhotos()
+	if err != nil {
+		app.logger.Error(err.Error())
+		os.Exit(1)
+	}
+	app.photoService = inmemorydatabase.NewPhotoService(allPhotos)
+
+	// load Section Photo files into Section Photo service
+	allSectionPhotos, err := app.loadSectionPhotos()
+	if err != nil {
+		app.logger.Error(err.Error())
+		os.Exit(1)
+	}
+	app.sectionPhotoService = inmemorydatabase.NewSectionPhotoService(allSectionPhotos)
+
+	// load Toobahsassins games into the Game service
+	gameStore, err := jsonstore.NewStore(dataDir, "toobahsassins.json")
+	if err != nil {
+		app.logger.Error(err.Error())
+		os.Exit(1)
+	}
+	gameService, err := jsondatabase.NewGameService(gameStore)
+	if err != nil {
+		app.logger.Error(err.Error())
+		os.Exit(1)
+	}
+	app.gameService = gameService
+
+	// derive Spoonsassins victories from the finished games
+	err = app.reportSpoonsassinsVictories()
+	if err != nil {
+		app.logger.Error(err.Error())
+		os.Exit(1)
+	}
+	app.purdoobahService = &spoonsassinsPurdoobahService{
+		IPurdoobahService: app.purdoobahService,
+		games:             gameService,
+	}
+
+	// load Cravers Hall of Fame applications into the Application service
+	applicationStore, err := jsonstore.NewStore(dataDir, "craver-applications.json")
+	if err != nil {
+		app.logger.Error(err.Error())
+		os.Exit(1)
+	}
+	applicationService, err := jsondatabase.NewApplicationService(applicationStore)
+	if err != nil {
+		app.logger.Error(err.Error())
+		os.Exit(1)
+	}
+	app.applicationService = applicationService
+
+	// load event RSVPs into the RSVP service
+	rsvpStore, err := jsonstore.NewStore(dataDir, "rsvps.json")
+	if err != nil {
+		app.logger.Error(err.Error())
+		os.Exit(1)
+	}
+	rsvpService, err := jsondatabase.NewRSVPService(rsvpStore)
+	if err != nil {
+		app.logger.Error(err.Error())
+		os.Exit(1)
+	}
+	app.rsvpService = rsvpService
+
+	// seat whoever fits from the waitlists, e.g. of events that have gotten bigger
+	err = app.settleRSVPs()
+	if err != nil {
+		app.logger.Error(err.Error())
+		os.Exit(1)
+	}
+
+	// locate every Purdoobah's hometown, reporting the ones that can't be found
+	err = app.resolveHometowns()
+	if err != nil {
+		app.logger.Error(err.Error())
+		os.Exit(1)
+	}
+
+	// delete the cached image variants and social cards that can't be asked for anymore, now that every static asset
+	// has been hashed and every page is known
+	prunedVariants, err := app.resizer.Prune(app.cacheBuster.IsCurrentHash)
+	if err != nil {
+		app.logger.Error(err.Error())
+		os.Exit(1)
+	}
+	currentSocialCards, err := app.currentSocialCards()
+	if err != nil {
+		app.logger.Error(err.Error())
+		os.Exit(1)
+	}
+	prunedSocialCards, err := app.socialCards.Prune(func(hash string) bool {
+		return currentSocialCards[hash]
+	})
+	if err != nil {
+		app.logger.Error(err.Error())
+		os.Exit(1)
+	}
+	app.logger.Info(fmt.Sprintf("pruned %d image variants and %d social cards", prunedVariants, prunedSocialCards))
+
+	// create the GraphQL schema over the Purdoobah and Tradition services
+	graphQL, err := createGraphQL(app.purdoobahService, app.traditionService)
+	if err != nil {
+		app.logger.Error(err.Error())
+		os.Exit(1)
+	}
+	app.graphQL = graphQL
+
 	// create HTML template cache
 	templateCache, err := app.newTemplateCache()
 	if err != nil {
@@ -166,11 +423,21 @@ func main() {
 		Transport: tr,
 	}
 
+	// create the routes
+	handler := app.routes()
+
+	// generate the OpenAPI document, validating it against the API routes
+	err = app.generateOpenAPIDocument()
+	if err != nil {
+		app.logger.Error(err.Error())
+		os.Exit(1)
+	}
+
 	// create the server
 	srv := &http.Server{
 		Addr:     addr,
 		ErrorLog: app.logger.(*logger.Logger).ErrorLog,
-		Handler:  app.routes(),
+		Handler:  handler,
 
 		IdleTimeout:  time.Minute,
 		ReadTimeout:  10 * time.Second,
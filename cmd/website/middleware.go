@@ -1,18 +1,31 @@
 package main
 
 import (
+	"crypto/sha256"
+	"crypto/subtle"
 	"fmt"
 	"net/http"
+	"strings"
+	"time"
 
 	"github.com/goddtriffin/helmet"
 	"github.com/purdoobahs/purdoobahs.com/internal/cachecontrol"
+	"github.com/purdoobahs/purdoobahs.com/internal/cors"
+	"github.com/purdoobahs/purdoobahs.com/internal/csrf"
+	"github.com/purdoobahs/purdoobahs.com/internal/graphqlapi"
+	"github.com/purdoobahs/purdoobahs.com/internal/httpheader"
+	"github.com/purdoobahs/purdoobahs.com/internal/purdoobahs"
+	"github.com/purdoobahs/purdoobahs.com/internal/ratelimit"
+	"github.com/purdoobahs/purdoobahs.com/internal/spamguard"
+	"github.com/purdoobahs/purdoobahs.com/internal/traditions"
+	"github.com/purdoobahs/purdoobahs.com/internal/trustedproxy"
 )
 
 func (app *application) logRequest(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		app.logger.Info(fmt.Sprintf(
 			"%s - %s %s %s",
-			r.RemoteAddr, r.Proto, r.Method, r.URL.RequestURI()),
+			trustedproxy.ClientIP(r), r.Proto, r.Method, r.URL.RequestURI()),
 		)
 
 		next.ServeHTTP(w, r)
@@ -24,7 +37,7 @@ func (app *application) recoverPanic(next http.Handler) http.Handler {
 		defer func() {
 			if err := recover(); err != nil {
 				w.Header().Set("Connection", "close")
-				app.serveError(w, fmt.Errorf("%s", err))
+				app.serveError(w, r, fmt.Errorf("%s", err))
 			}
 		}()
 
@@ -38,11 +51,169 @@ func createCacheControl() *cachecontrol.CacheControl {
 	// set which route prefixes to enable ForeverCache (1 year)
 	cc.ForeverCacheRoutePrefixes = []string{
 		"/static/",
+		"/image?",
+		"/social-card/",
 	}
 
 	return cc
 }
 
+func createCSRF(secure bool, failureHandler http.Handler) *csrf.CSRF {
+	c := csrf.NewCSRF()
+	c.FailureHandler = failureHandler
+
+	// cookies can only be restricted to HTTPS in production, as development is served over plain HTTP
+	c.Secure = secure
+
+	// forms are short, so nobody needs to send more than this
+	c.MaxBodyBytes = 64 * 1024
+
+	// only routes with forms need a token
+	c.RoutePrefixes = []string{
+		"/admin/",
+		"/cravers-hall-of-fame/apply",
+		"/events/",
+	}
+
+	return c
+}
+
+func createSpamGuard() (*spamguard.SpamGuard, error) {
+	sg, err := spamguard.NewSpamGuard()
+	if err != nil {
+		return sg, err
+	}
+
+	// nobody fills in a form in under 3 seconds, and nobody leaves one open for more than a day
+	sg.MinDelay = 3 * time.Second
+	sg.MaxAge = 24 * time.Hour
+
+	return sg, nil
+}
+
+// requireAdmin guards the admin UI behind HTTP Basic authentication. Any username is accepted, as long as the password
+// matches; the whole admin UI pretends to not exist when no password is configured.
+func (app *application) requireAdmin(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if app.adminPassword == "" {
+			app.pageNotFound(w, r)
+			return
+		}
+
+		// hash both passwords first, so that the comparison doesn't leak the password's length
+		_, password, ok := r.BasicAuth()
+		want := sha256.Sum256([]byte(app.adminPassword))
+		got := sha256.Sum256([]byte(password))
+		if !ok || subtle.ConstantTimeCompare(want[:], got[:]) != 1 {
+			w.Header().Set(httpheader.WwwAuthenticate.String(), `Basic realm="Purdoobahs Admin", charset="UTF-8"`)
+			app.clientError(w, r, http.StatusUnauthorized)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func createCors(allowedOrigins string) *cors.Cors {
+	c := cors.NewCors()
+
+	// only the JSON and GraphQL APIs are shared with other origins
+	c.RoutePrefixes = []string{
+		"/api/v1/",
+		"/api/graphql",
+	}
+
+	// every origin may read the public API; a comma-separated list of origins overrides this
+	c.AllowedOrigins = []string{"*"}
+	if strings.TrimSpace(allowedOrigins) != "" {
+		c.AllowedOrigins = strings.Split(allowedOrigins, ",")
+		for i := range c.AllowedOrigins {
+			c.AllowedOrigins[i] = strings.TrimSpace(c.AllowedOrigins[i])
+		}
+	}
+
+	c.AllowedMethods = []string{http.MethodGet, http.MethodHead, http.MethodPost}
+	c.AllowedHeaders = []string{
+		httpheader.Accept.String(),
+		httpheader.Authorization.String(),
+		httpheader.ContentType.String(),
+	}
+	c.ExposedHeaders = []string{
+		httpheader.ContentDisposition.String(),
+		httpheader.ExperimentalRateLimitLimit.String(),
+		httpheader.ExperimentalRateLimitPolicy.String(),
+		httpheader.ExperimentalRateLimitRemaining.String(),
+		httpheader.ExperimentalRateLimitReset.String(),
+		httpheader.RetryAfter.String(),
+		httpheader.NonstandardXRequestId.String(),
+	}
+	c.MaxAge = 2 * time.Hour
+
+	return c
+}
+
+// rateLimitKey identifies a client by their API key when they present a known one, otherwise by their IP address.
+func (app *application) rateLimitKey(r *http.Request) string {
+	const bearerPrefix = "Bearer "
+	authorization := r.Header.Get(httpheader.Authorization.String())
+	if strings.HasPrefix(authorization, bearerPrefix) {
+		apiKey := strings.TrimPrefix(authorization, bearerPrefix)
+		if app.apiKeys[apiKey] {
+			return fmt.Sprintf("key:%s", apiKey)
+		}
+	}
+
+	return fmt.Sprintf("ip:%s", trustedproxy.ClientIP(r))
+}
+
+func createGraphQL(
+	purdoobahService purdoobahs.IPurdoobahService,
+	traditionService traditions.ITraditionService,
+) (*graphqlapi.GraphQL, error) {
+	// lists are assumed to hold 10 items, so at most three lists can be nested inside of each other
+	// (e.g. every Purdoobah's sections' members)
+	return graphqlapi.NewGraphQL(purdoobahService, traditionService, 6, 2000)
+}
+
+func createRateLimiter(keyFunc func(r *http.Request) string, exceededHandler http.Handler) *ratelimit.RateLimiter {
+	rl := ratelimit.NewRateLimiter(keyFunc)
+	rl.ExceededHandler = exceededHandler
+
+	// the most specific route prefix wins
+	rl.AddGroup(ratelimit.NewGroup("api", "/api/", 120, time.Minute, 10000))
+	rl.AddGroup(ratelimit.NewGroup("intake", "/api/v1/scitylana", 30, time.Minute, 10000))
+	rl.AddGroup(ratelimit.NewGroup("forms", "/cravers-hall-of-fame/apply", 20, time.Minute, 10000))
+
+	// images are drawn on demand, which is costly the first time; a page can show well over a hundred of them, though
+	rl.AddGroup(ratelimit.NewGroup("images", "/image", 600, time.Minute, 10000))
+	rl.AddGroup(ratelimit.NewGroup("social-cards", "/social-card/", 60, time.Minute, 10000))
+
+	// only RSVPs are limited, not the event pages they're sent from
+	rsvps := ratelimit.NewGroup("rsvp", "/events/", 20, time.Minute, 10000)
+	rsvps.Match = func(r *http.Request) bool {
+		return r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/rsvp")
+	}
+	rl.AddGroup(rsvps)
+
+	return rl
+}
+
+func createTrustedProxy(trustedProxies string) (*trustedproxy.TrustedProxy, error) {
+	// default to loopback only; anything else on a private network could spoof its clients' IPs, so the ingress's
+	// networks have to be configured explicitly
+	cidrs := []string{
+		"127.0.0.0/8",
+		"::1/128",
+	}
+
+	// a comma-separated list of CIDRs overrides the defaults
+	if strings.TrimSpace(trustedProxies) != "" {
+		cidrs = strings.Split(trustedProxies, ",")
+	}
+
+	return trustedproxy.NewTrustedProxy(cidrs)
+}
+
 func createHelmet() *helmet.Helmet {
 	h := helmet.Empty()
 
@@ -53,12 +224,12 @@ func createHelmet() *helmet.Helmet {
 
 		// these don't use 'default' as a fallback
 		helmet.DirectiveBaseURI:    {helmet.SourceNone},
-		helmet.DirectiveFormAction: {helmet.SourceNone},
+		helmet.DirectiveFormAction: {helmet.SourceSelf},
 
 		// these need to be not 'none'
 		helmet.DirectiveFrameAncestors:      {helmet.SourceSelf},
 		helmet.DirectiveFrameSrc:            {helmet.SourceSelf, "https://www.youtube.com/"},
-		helmet.DirectiveImgSrc:              {helmet.SourceSelf, helmet.SourceReportSample},
+		helmet.DirectiveImgSrc:              {helmet.SourceSelf, helmet.SourceData, helmet.SourceReportSample}, // data: for image placeholders
 		helmet.DirectiveNavigateTo:          {helmet.SourceSelf, helmet.SourceReportSample},
 		helmet.DirectiveObjectSrc:           {helmet.SourceSelf},
 		helmet.DirectiveReportTo:            {}, // TODO add support
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/purdoobahs/purdoobahs.com/internal/apierror"
+	"github.com/purdoobahs/purdoobahs.com/internal/cachecontrol"
+	"github.com/purdoobahs/purdoobahs.com/internal/contentnegotiation"
+	"github.com/purdoobahs/purdoobahs.com/internal/httpheader"
+	"github.com/purdoobahs/purdoobahs.com/internal/mimetype"
+)
+
+// baseURL is the origin that absolute links to the site are built with.
+const baseURL = "https://www.purdoobahs.com"
+
+// pageFormat is a representation of a page.
+type pageFormat string
+
+const (
+	pageFormatHTML   pageFormat = "html"
+	pageFormatJSON   pageFormat = "json"
+	pageFormatJSONLD pageFormat = "jsonld"
+)
+
+// pageOffers are the representations of every page that also has a data representation.
+// HTML comes first, so that browsers (and anything else without a preference) keep getting the page.
+var pageOffers = []contentnegotiation.Offer{
+	{Format: string(pageFormatHTML), MimeType: mimetype.Html.String()},
+	{Format: string(pageFormatJSON), MimeType: mimetype.Json.String()},
+	{Format: string(pageFormatJSONLD), MimeType: mimetype.LdJson.String()},
+}
+
+// negotiatePage returns the representation of a page that the client asked for.
+//
+// Pages are for humans first, so a client asking for a representation that isn't offered gets the HTML page instead
+// of an error.
+func (app *application) negotiatePage(w http.ResponseWriter, r *http.Request) pageFormat {
+	// the response differs by Accept header
+	cachecontrol.Vary(w, httpheader.Accept.String())
+
+	offer, err := contentnegotiation.Negotiate(r, pageOffers)
+	if err != nil {
+		return pageFormatHTML
+	}
+	return pageFormat(offer.Format)
+}
+
+// pageNotFoundAs renders a 404 in the negotiated representation.
+func (app *application) pageNotFoundAs(w http.ResponseWriter, r *http.Request, format pageFormat, err error) {
+	if format == pageFormatHTML {
+		app.pageNotFound(w, r)
+		return
+	}
+	app.apiError(w, r, apierror.NotFound(err.Error()))
+}
+
+// writePageData sends out the data behind a page: data as plain JSON, or linkedData as JSON-LD.
+func (app *application) writePageData(w http.ResponseWriter, r *http.Request, format pageFormat, data, linkedData interface{}) {
+	if format == pageFormatJSON {
+		app.writeJSON(w, r, http.StatusOK, data)
+		return
+	}
+
+	// convert to JSON bytes
+	b, err := json.Marshal(linkedData)
+	if err != nil {
+		app.serveError(w, r, err)
+		return
+	}
+
+	// send it out
+	w.Header().Set(
+		httpheader.ContentType.String(),
+		fmt.Sprintf("%s; charset=utf-8", mimetype.LdJson.String()),
+	)
+	_, err = w.Write(b)
+	if err != nil {
+		app.logger.Error(err.Error())
+	}
+}
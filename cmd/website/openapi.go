@@ -0,0 +1,982 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/purdoobahs/purdoobahs.com/internal/httpheader"
+	"github.com/purdoobahs/purdoobahs.com/internal/mimetype"
+	"github.com/purdoobahs/purdoobahs.com/internal/openapi"
+
+	"github.com/gorilla/mux"
+)
+
+// openAPIRoutePrefix is the route prefix of every API route that must be documented in the OpenAPI document.
+const openAPIRoutePrefix = "/api/v1/"
+
+// generateOpenAPIDocument builds the OpenAPI document of the JSON API and validates it against the registered routes.
+func (app *application) generateOpenAPIDocument() error {
+	doc := openapi.NewDocument(openapi.Info{
+		Title:       "Purdoobahs API",
+		Description: "The public JSON API of the Purdue All-American Marching Band Toobah section.",
+		Version:     "1.0.0",
+	})
+	doc.Servers = []openapi.Server{
+		{URL: "https://www.purdoobahs.com", Description: "Production"},
+	}
+	doc.Tags = []openapi.Tag{
+		{Name: "achievements", Description: "Honors a Purdoobah can hold, and who holds them"},
+		{Name: "events", Description: "Game days and other events on the section's calendar"},
+		{Name: "generic", Description: "Health and metadata of the API itself"},
+		{Name: "cravers", Description: "Inductees of the White Castle Cravers Hall of Fame"},
+		{Name: "purdoobah", Description: "Every Purdoobah that ever marched"},
+		{Name: "section", Description: "Purdoobahs grouped by the year they marched"},
+		{Name: "stats", Description: "Statistics of the section over time"},
+		{Name: "toobahsassins", Description: "Games of Toobahsassins (a.k.a. Spoonsassins)"},
+		{Name: "tradition", Description: "Traditions of the section"},
+	}
+
+	// component schemas
+	err := app.addOpenAPISchemas(doc)
+	if err != nil {
+		return err
+	}
+
+	// operations
+	for _, endpoint := range app.openAPIEndpoints() {
+		err := doc.AddOperation(endpoint.Path, endpoint.Method, endpoint.Operation)
+		if err != nil {
+			return err
+		}
+	}
+
+	// make sure the document and the router agree with each other
+	err = app.validateOpenAPIDocument(doc)
+	if err != nil {
+		return err
+	}
+
+	b, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	app.openAPIDocument = doc
+	app.openAPIDocumentJSON = b
+	return nil
+}
+
+// addOpenAPISchemas generates component schemas from the asset JSON Schemas, plus the fields generated on load.
+func (app *application) addOpenAPISchemas(doc *openapi.Document) error {
+	imageMetadataSchema := openapi.Schema{
+		"type":        "object",
+		"description": "Generated metadata",
+		"properties": map[string]interface{}{
+			"image": openapi.Schema{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"file":   openapi.Schema{"type": "string", "description": "Cache-busted path of the image"},
+					"alt":    openapi.Schema{"type": "string", "description": "Alt text of the image"},
+					"width":  openapi.Schema{"type": "integer", "description": "Width of the image in pixels"},
+					"height": openapi.Schema{"type": "integer", "description": "Height of the image in pixels"},
+					"dominant_color": openapi.Schema{
+						"type":        "string",
+						"pattern":     "^#[0-9a-f]{6}$",
+						"description": "Most common color of the image, as a hex code",
+					},
+					"placeholder": openapi.Schema{
+						"type":        "string",
+						"format":      "uri",
+						"description": "Tiny PNG version of the image as a data URL, to show blurred while the image loads. Left out of lists",
+					},
+				},
+			},
+		},
+	}
+
+	// Purdoobah
+	purdoobahSchema, err := openapi.LoadJSONSchema("./assets/purdoobahs/_purdoobah.schema.json")
+	if err != nil {
+		return err
+	}
+	purdoobahSchema.AddProperty("id", openapi.Schema{
+		"type":        "string",
+		"description": "This Purdoobah's unique ID (the name of their asset file)",
+	}, true)
+	purdoobahSchema.AddProperty("metadata", imageMetadataSchema, true)
+	if educationSchema, ok := purdoobahSchema["properties"].(map[string]interface{})["education"].(map[string]interface{}); ok {
+		openapi.Schema(educationSchema).AddProperty("effective_year", openapi.Schema{
+			"type":        "string",
+			"description": "Generated year in school, derived from the years marched (the stored year is only honored as an override while marching)",
+			"enum":        []interface{}{"freshman", "sophomore", "junior", "senior", "super-senior", "alumni"},
+		}, true)
+	}
+	if achievementsSchema, ok := purdoobahSchema["properties"].(map[string]interface{})["achievements"].(map[string]interface{}); ok {
+		openapi.Schema(achievementsSchema).AddProperty("student_leader", openapi.Schema{
+			"type":        "array",
+			"items":       openapi.Schema{"type": "integer"},
+			"description": "Generated years this Purdoobah was a section leader or assistant section leader; use `leadership` instead",
+			"deprecated":  true,
+		}, false)
+	}
+	purdoobahSchema.AddProperty("season", openapi.Schema{
+		"type":        "object",
+		"description": "Generated when this Purdoobah is a member of a section: what was true of them that season",
+		"required":    []interface{}{"year", "class_standing", "major"},
+		"properties": map[string]interface{}{
+			"year":           openapi.Schema{"type": "integer"},
+			"class_standing": openapi.Schema{"type": "string", "enum": []interface{}{"freshman", "sophomore", "junior", "senior", "super-senior"}},
+			"major":          openapi.Schema{"type": "string"},
+			"minor":          openapi.Schema{"type": "string"},
+			"role":           openapi.Schema{"type": "string"},
+		},
+	}, false)
+	doc.Components.Schemas["Purdoobah"] = purdoobahSchema
+
+	// Tradition
+	traditionSchema, err := openapi.LoadJSONSchema("./assets/traditions/_tradition.schema.json")
+	if err != nil {
+		return err
+	}
+	traditionSchema.AddProperty("id", openapi.Schema{
+		"type":        "string",
+		"description": "This tradition's unique ID (the name of its asset file)",
+	}, true)
+	traditionSchema.AddProperty("metadata", imageMetadataSchema, true)
+	doc.Components.Schemas["Tradition"] = traditionSchema
+
+	// Craver
+	craverSchema, err := openapi.LoadJSONSchema("./assets/cravers/_craver.schema.json")
+	if err != nil {
+		return err
+	}
+	craverSchema.AddProperty("id", openapi.Schema{
+		"type":        "string",
+		"description": "This Craver's unique ID (the name of their asset file)",
+	}, true)
+	craverSchema.AddProperty("metadata", imageMetadataSchema, true)
+	doc.Components.Schemas["Craver"] = craverSchema
+
+	// Achievement
+	achievementSchema, err := openapi.LoadJSONSchema("./assets/achievements/_achievement.schema.json")
+	if err != nil {
+		return err
+	}
+	achievementSchema.AddProperty("id", openapi.Schema{
+		"type":        "string",
+		"description": "This achievement's unique ID (the name of its asset file)",
+	}, true)
+	doc.Components.Schemas["Achievement"] = achievementSchema
+
+	// Section
+	sectionProperties := make(map[string]interface{})
+	for _, bucket := range []string{"StudentLeaders", "SuperSeniors", "Seniors", "Juniors", "Sophomores", "Freshmen"} {
+		sectionProperties[bucket] = openapi.ArrayOf(openapi.Ref("Purdoobah"))
+	}
+	doc.Components.Schemas["Section"] = openapi.Schema{
+		"type":        "object",
+		"title":       "Section",
+		"description": "The current section, bucketed by leadership and year in school",
+		"properties":  sectionProperties,
+	}
+
+	// Stats
+	integer := openapi.Schema{"type": "integer"}
+	number := openapi.Schema{"type": "number"}
+	object := func(properties map[string]interface{}) openapi.Schema {
+		required := make([]interface{}, 0, len(properties))
+		for name := range properties {
+			required = append(required, name)
+		}
+		sort.Slice(required, func(i, j int) bool { return required[i].(string) < required[j].(string) })
+		return openapi.Schema{"type": "object", "required": required, "properties": properties}
+	}
+	distributionSchema := object(map[string]interface{}{
+		"counts": openapi.ArrayOf(object(map[string]interface{}{
+			"name":    openapi.Schema{"type": "string"},
+			"count":   integer,
+			"percent": number,
+		})),
+		"unknown": integer,
+	})
+	rateSchema := object(map[string]interface{}{
+		"count":   integer,
+		"total":   integer,
+		"percent": number,
+	})
+	statsSchema := object(map[string]interface{}{
+		"total_purdoobahs": integer,
+		"unknown_history":  integer,
+		"years": openapi.ArrayOf(object(map[string]interface{}{
+			"year":            integer,
+			"size":            integer,
+			"student_leaders": integer,
+			"class_standing": object(map[string]interface{}{
+				"freshmen":      integer,
+				"sophomores":    integer,
+				"juniors":       integer,
+				"seniors":       integer,
+				"super_seniors": integer,
+			}),
+		})),
+		"home_states":     distributionSchema,
+		"majors":          distributionSchema,
+		"kappa_kappa_psi": rateSchema,
+		"tau_beta_sigma":  rateSchema,
+		"average_tenure":  number,
+	})
+	statsSchema["title"] = "Stats"
+	statsSchema["description"] = "An aggregated view of the section over time"
+	doc.Components.Schemas["Stats"] = statsSchema
+
+	// Cohorts
+	retentionSchema := object(map[string]interface{}{
+		"season":   integer,
+		"observed": openapi.Schema{"type": "boolean", "description": "False if the season hasn't happened yet or falls in a gap of the archives"},
+		"count":    integer,
+		"percent":  number,
+	})
+	retentionSchema["properties"].(map[string]interface{})["year"] = integer
+	cohortsSchema := object(map[string]interface{}{
+		"cohorts": openapi.ArrayOf(object(map[string]interface{}{
+			"year":      integer,
+			"size":      integer,
+			"retention": openapi.ArrayOf(retentionSchema),
+			"returners": integer,
+		})),
+		"average":         openapi.ArrayOf(retentionSchema),
+		"unknown_history": integer,
+	})
+	cohortsSchema["title"] = "Cohorts"
+	cohortsSchema["description"] = "The retention of every freshman cohort, where a cohort is everyone whose first season was the same year"
+	doc.Components.Schemas["Cohorts"] = cohortsSchema
+
+	// Directory
+	directoryEntrySchema := object(map[string]interface{}{
+		"purdoobah":                 openapi.Ref("Purdoobah"),
+		"graduation_year_estimated": openapi.Schema{"type": "boolean", "description": "True if the graduation year was estimated from the years marched"},
+	})
+	directoryEntrySchema["properties"].(map[string]interface{})["graduation_year"] = integer
+	directorySchema := object(map[string]interface{}{
+		"filter": openapi.Schema{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"industry":        openapi.Schema{"type": "string"},
+				"graduation_year": integer,
+				"state":           openapi.Schema{"type": "string"},
+			},
+		},
+		"alumni": openapi.ArrayOf(directoryEntrySchema),
+		"options": object(map[string]interface{}{
+			"industries":       openapi.ArrayOf(openapi.Schema{"type": "string"}),
+			"graduation_years": openapi.ArrayOf(integer),
+			"states":           openapi.ArrayOf(openapi.Schema{"type": "string"}),
+		}),
+	})
+	directorySchema["title"] = "Directory"
+	directorySchema["description"] = "Every alumnus matching the filter, along with the values each filter can take"
+	doc.Components.Schemas["Directory"] = directorySchema
+
+	// Hometowns
+	hometownsSchema := object(map[string]interface{}{
+		"type": openapi.Schema{"type": "string", "enum": []interface{}{"FeatureCollection"}},
+		"features": openapi.ArrayOf(object(map[string]interface{}{
+			"type": openapi.Schema{"type": "string", "enum": []interface{}{"Feature"}},
+			"geometry": object(map[string]interface{}{
+				"type":        openapi.Schema{"type": "string", "enum": []interface{}{"Point"}},
+				"coordinates": openapi.Schema{"type": "array", "items": number, "minItems": 2, "maxItems": 2, "description": "[longitude, latitude]"},
+			}),
+			"properties": object(map[string]interface{}{
+				"city":       openapi.Schema{"type": "string"},
+				"state":      openapi.Schema{"type": "string", "description": "The state's USPS code"},
+				"count":      integer,
+				"purdoobahs": openapi.ArrayOf(openapi.Schema{"type": "string", "description": "Purdoobah ID"}),
+			}),
+		})),
+	})
+	hometownsSchema["title"] = "Hometowns"
+	hometownsSchema["description"] = "A GeoJSON FeatureCollection with one point per hometown, most common first"
+	doc.Components.Schemas["Hometowns"] = hometownsSchema
+
+	// Event
+	eventSchema, err := openapi.LoadJSONSchema("./assets/events/_event.schema.json")
+	if err != nil {
+		return err
+	}
+	eventSchema.AddProperty("id", openapi.Schema{
+		"type":        "string",
+		"description": "This event's unique ID (the name of its asset file)",
+	}, true)
+	eventSchema.AddProperty("start", openapi.Schema{
+		"type":        "string",
+		"format":      "date-time",
+		"description": "When the event starts: its call time, or the start of its date if it lasts all day",
+	}, true)
+	eventSchema.AddProperty("end", openapi.Schema{
+		"type":        "string",
+		"format":      "date-time",
+		"description": "When the event is over, if it's known (exclusive for events that last all day)",
+	}, false)
+	eventSchema.AddProperty("all_day", openapi.Schema{"type": "boolean"}, true)
+	doc.Components.Schemas["Event"] = eventSchema
+
+	// Achievement Holders
+	holderSchema := object(map[string]interface{}{
+		"purdoobah": openapi.Ref("Purdoobah"),
+	})
+	holderSchema["properties"].(map[string]interface{})["years"] = openapi.Schema{
+		"type":        "array",
+		"items":       integer,
+		"description": "The years the achievement was earned, if it's earned per year",
+	}
+	achievementHoldersSchema := openapi.Schema{
+		"allOf": []interface{}{
+			openapi.Ref("Achievement"),
+			object(map[string]interface{}{
+				"holders": openapi.ArrayOf(holderSchema),
+			}),
+		},
+	}
+	achievementHoldersSchema["title"] = "AchievementHolders"
+	achievementHoldersSchema["description"] = "An achievement, along with every Purdoobah holding it"
+	doc.Components.Schemas["AchievementHolders"] = achievementHoldersSchema
+
+	// Leaders
+	role := openapi.Schema{"type": "string", "enum": []interface{}{"section-leader", "assistant-section-leader", "committee-chair"}}
+	termSchema := object(map[string]interface{}{
+		"role":  role,
+		"title": openapi.Schema{"type": "string"},
+		"start": integer,
+		"end":   integer,
+	})
+	termSchema["properties"].(map[string]interface{})["committee"] = openapi.Schema{"type": "string"}
+	leaderSchema := object(map[string]interface{}{
+		"purdoobah": openapi.Ref("Purdoobah"),
+		"role":      role,
+		"title":     openapi.Schema{"type": "string"},
+		"term":      termSchema,
+	})
+	leaderSchema["properties"].(map[string]interface{})["committee"] = openapi.Schema{"type": "string"}
+	leadersSchema := openapi.ArrayOf(object(map[string]interface{}{
+		"year":    integer,
+		"leaders": openapi.ArrayOf(leaderSchema),
+	}))
+	leadersSchema["title"] = "Leaders"
+	leadersSchema["description"] = "Everybody who held a leadership role, year by year, newest first"
+	doc.Components.Schemas["Leaders"] = leadersSchema
+
+	// Toobahsassins Game
+	dateTime := openapi.Schema{"type": "string", "format": "date-time"}
+	gameSchema := object(map[string]interface{}{
+		"id":         openapi.Schema{"type": "string"},
+		"season":     integer,
+		"created_at": dateTime,
+		"players": openapi.ArrayOf(object(map[string]interface{}{
+			"id":           openapi.Schema{"type": "string", "description": "Purdoobah ID"},
+			"eliminated":   openapi.Schema{"type": "boolean"},
+			"eliminations": integer,
+		})),
+		"eliminations": openapi.ArrayOf(object(map[string]interface{}{
+			"assassin": openapi.Schema{"type": "string", "description": "Purdoobah ID"},
+			"victim":   openapi.Schema{"type": "string", "description": "Purdoobah ID"},
+			"at":       dateTime,
+		})),
+	})
+	gameSchema["properties"].(map[string]interface{})["completed_at"] = dateTime
+	gameSchema["properties"].(map[string]interface{})["victor"] = openapi.Schema{"type": "string", "description": "Purdoobah ID of the last player standing, once the game is over"}
+	gameSchema["title"] = "Game"
+	gameSchema["description"] = "A game of Toobahsassins, with the players in leaderboard order and nobody's target revealed"
+	doc.Components.Schemas["Game"] = gameSchema
+
+	// Status
+	doc.Components.Schemas["Status"] = openapi.Schema{
+		"type":     "object",
+		"title":    "Status",
+		"required": []interface{}{"status"},
+		"properties": map[string]interface{}{
+			"status": openapi.Schema{"type": "string", "enum": []interface{}{"success"}},
+		},
+	}
+
+	// Error
+	doc.Components.Schemas["Error"] = openapi.Schema{
+		"type":        "object",
+		"title":       "Error",
+		"description": "The envelope of every API error response",
+		"required":    []interface{}{"error"},
+		"properties": map[string]interface{}{
+			"error": openapi.Schema{
+				"type":     "object",
+				"required": []interface{}{"status", "code", "message"},
+				"properties": map[string]interface{}{
+					"status":     openapi.Schema{"type": "integer", "description": "The HTTP status code"},
+					"code":       openapi.Schema{"type": "string", "description": "A machine-readable error code"},
+					"message":    openapi.Schema{"type": "string", "description": "A human-readable explanation"},
+					"details":    openapi.Schema{"type": "object", "additionalProperties": openapi.Schema{"type": "string"}},
+					"request_id": openapi.Schema{"type": "string", "description": "Echoes the X-Request-ID header"},
+				},
+			},
+		},
+	}
+
+	return nil
+}
+
+// openAPIEndpoints documents every route of the JSON API.
+func (app *application) openAPIEndpoints() []openapi.Endpoint {
+	errorResponse := func(description string) *openapi.Response {
+		return &openapi.Response{
+			Description: description,
+			Content:     openapi.JSONContent(openapi.Ref("Error")),
+		}
+	}
+	tooManyRequests := errorResponse("Rate limit exceeded")
+
+	nameParameter := openapi.Parameter{
+		Name:        "name",
+		In:          "path",
+		Description: "The ID of the Purdoobah (the name of their asset file)",
+		Required:    true,
+		Schema:      openapi.Schema{"type": "string"},
+		Example:     "juggs",
+	}
+	traditionNameParameter := openapi.Parameter{
+		Name:        "name",
+		In:          "path",
+		Description: "The ID of the tradition (the name of its asset file)",
+		Required:    true,
+		Schema:      openapi.Schema{"type": "string"},
+		Example:     "bean-town",
+	}
+	yearParameter := openapi.Parameter{
+		Name:        "year",
+		In:          "path",
+		Description: "The year the section marched (-1 for Purdoobahs whose marching history is unknown)",
+		Required:    true,
+		Schema:      openapi.Schema{"type": "integer"},
+		Example:     "2019",
+	}
+	directoryParameters := []openapi.Parameter{
+		{
+			Name:        "industry",
+			In:          "query",
+			Description: "Only list alumni working in this industry",
+			Required:    false,
+			Schema:      openapi.Schema{"type": "string"},
+		},
+		{
+			Name:        "graduation_year",
+			In:          "query",
+			Description: "Only list alumni who graduated this year (estimated from the years marched unless recorded)",
+			Required:    false,
+			Schema:      openapi.Schema{"type": "integer"},
+		},
+		{
+			Name:        "state",
+			In:          "query",
+			Description: "Only list alumni currently living in this state",
+			Required:    false,
+			Schema:      openapi.Schema{"type": "string"},
+		},
+	}
+	mapYearParameter := openapi.Parameter{
+		Name:        "year",
+		In:          "query",
+		Description: "Only map the section that marched this year; every Purdoobah is mapped if unset",
+		Required:    false,
+		Schema:      openapi.Schema{"type": "integer"},
+		Example:     "2019",
+	}
+	craverNameParameter := openapi.Parameter{
+		Name:        "name",
+		In:          "path",
+		Description: "The ID of the Craver (the name of their asset file)",
+		Required:    true,
+		Schema:      openapi.Schema{"type": "string"},
+		Example:     "the-purdoobahs",
+	}
+	achievementIDParameter := openapi.Parameter{
+		Name:        "id",
+		In:          "path",
+		Description: "The ID of the achievement (the name of its asset file)",
+		Required:    true,
+		Schema:      openapi.Schema{"type": "string"},
+		Example:     "spoonsassins-victor",
+	}
+	eventNameParameter := openapi.Parameter{
+		Name:        "name",
+		In:          "path",
+		Description: "The ID of the event (the name of its asset file)",
+		Required:    true,
+		Schema:      openapi.Schema{"type": "string"},
+	}
+	gameIDParameter := openapi.Parameter{
+		Name:        "id",
+		In:          "path",
+		Description: "The ID of the game",
+		Required:    true,
+		Schema:      openapi.Schema{"type": "string"},
+	}
+	formatParameter := openapi.Parameter{
+		Name:        "format",
+		In:          "query",
+		Description: "Overrides the Accept header: json, csv, or ndjson. JSON is sent unless the Accept header names another format",
+		Required:    false,
+		Schema:      openapi.Schema{"type": "string", "enum": []interface{}{"json", "csv", "ndjson"}},
+		Example:     "json",
+	}
+
+	singleFormatParameter := openapi.Parameter{
+		Name:        "format",
+		In:          "query",
+		Description: "Only json is offered",
+		Required:    false,
+		Schema:      openapi.Schema{"type": "string", "enum": []interface{}{"json"}},
+		Example:     "json",
+	}
+
+	// lists of Purdoobahs can also be exported, as negotiated by the Accept header or the format parameter
+	purdoobahListContent := func() map[string]*openapi.MediaType {
+		content := openapi.JSONContent(openapi.ArrayOf(openapi.Ref("Purdoobah")))
+		content[mimetype.Csv.String()] = &openapi.MediaType{Schema: openapi.Schema{"type": "string"}}
+		content[mimetype.Ndjson.String()] = &openapi.MediaType{Schema: openapi.Schema{"type": "string"}}
+		return content
+	}
+
+	return []openapi.Endpoint{
+		{
+			Method: "GET",
+			Path:   "/api/v1/health",
+			Operation: &openapi.Operation{
+				OperationID: "healthCheck",
+				Summary:     "Checks that the API is up",
+				Tags:        []string{"generic"},
+				Responses: map[string]*openapi.Response{
+					"200": {Description: "The API is up", Content: openapi.JSONContent(openapi.Ref("Status"))},
+					"429": tooManyRequests,
+				},
+			},
+		},
+		{
+			Method: "GET",
+			Path:   "/api/v1/openapi.json",
+			Operation: &openapi.Operation{
+				OperationID: "openAPIDocument",
+				Summary:     "This OpenAPI document",
+				Tags:        []string{"generic"},
+				Responses: map[string]*openapi.Response{
+					"200": {Description: "The OpenAPI document", Content: openapi.JSONContent(openapi.Schema{"type": "object"})},
+					"429": tooManyRequests,
+				},
+			},
+		},
+		{
+			Method: "POST",
+			Path:   "/api/v1/scitylana",
+			Operation: &openapi.Operation{
+				OperationID: "recordPageview",
+				Summary:     "Records a pageview",
+				Description: "Forwards a pageview to our privacy-friendly analytics provider. Used by the website itself.",
+				Tags:        []string{"generic"},
+				RequestBody: &openapi.RequestBody{
+					Required: true,
+					Content: map[string]*openapi.MediaType{
+						"application/x-www-form-urlencoded": {
+							Schema: openapi.Schema{
+								"type":     "object",
+								"required": []interface{}{"url", "screen_width"},
+								"properties": map[string]interface{}{
+									"url":          openapi.Schema{"type": "string"},
+									"referrer":     openapi.Schema{"type": "string"},
+									"user_agent":   openapi.Schema{"type": "string"},
+									"screen_width": openapi.Schema{"type": "integer"},
+								},
+							},
+						},
+					},
+				},
+				Responses: map[string]*openapi.Response{
+					"200": {Description: "The pageview was recorded", Content: openapi.JSONContent(openapi.Ref("Status"))},
+					"400": errorResponse("The form is malformed"),
+					"429": tooManyRequests,
+				},
+			},
+		},
+		{
+			Method: "GET",
+			Path:   "/api/v1/purdoobah/all",
+			Operation: &openapi.Operation{
+				OperationID: "allPurdoobahs",
+				Summary:     "Lists every Purdoobah",
+				Tags:        []string{"purdoobah"},
+				Parameters:  []openapi.Parameter{formatParameter},
+				Responses: map[string]*openapi.Response{
+					"200": {
+						Description: "Every Purdoobah, sorted by name",
+						Content:     purdoobahListContent(),
+					},
+					"400": errorResponse("The format is unknown"),
+					"429": tooManyRequests,
+				},
+			},
+		},
+		{
+			Method: "GET",
+			Path:   "/api/v1/purdoobah/{name}",
+			Operation: &openapi.Operation{
+				OperationID: "purdoobahByName",
+				Summary:     "Gets a single Purdoobah",
+				Tags:        []string{"purdoobah"},
+				Parameters:  []openapi.Parameter{nameParameter, singleFormatParameter},
+				Responses: map[string]*openapi.Response{
+					"200": {Description: "The Purdoobah", Content: openapi.JSONContent(openapi.Ref("Purdoobah"))},
+					"400": errorResponse("The format is unknown"),
+					"404": errorResponse("No Purdoobah exists with that name"),
+					"429": tooManyRequests,
+				},
+			},
+		},
+		{
+			Method: "GET",
+			Path:   "/api/v1/section/current",
+			Operation: &openapi.Operation{
+				OperationID: "currentSection",
+				Summary:     "Gets the section marching this academic year",
+				Tags:        []string{"section"},
+				Responses: map[string]*openapi.Response{
+					"200": {Description: "The current section", Content: openapi.JSONContent(openapi.Ref("Section"))},
+					"429": tooManyRequests,
+				},
+			},
+		},
+		{
+			Method: "GET",
+			Path:   "/api/v1/section/{year}",
+			Operation: &openapi.Operation{
+				OperationID: "sectionByYear",
+				Summary:     "Lists every Purdoobah that marched during a year",
+				Tags:        []string{"section"},
+				Parameters:  []openapi.Parameter{yearParameter, formatParameter},
+				Responses: map[string]*openapi.Response{
+					"200": {
+						Description: "The section, sorted by name",
+						Content:     purdoobahListContent(),
+					},
+					"400": errorResponse("The year isn't an integer, or the format is unknown"),
+					"404": errorResponse("No Purdoobah marched during that year"),
+					"429": tooManyRequests,
+				},
+			},
+		},
+		{
+			Method: "GET",
+			Path:   "/api/v1/stats",
+			Operation: &openapi.Operation{
+				OperationID: "stats",
+				Summary:     "Aggregated statistics of the section over time",
+				Tags:        []string{"stats"},
+				Responses: map[string]*openapi.Response{
+					"200": {Description: "The statistics", Content: openapi.JSONContent(openapi.Ref("Stats"))},
+					"429": tooManyRequests,
+				},
+			},
+		},
+		{
+			Method: "GET",
+			Path:   "/api/v1/stats/cohorts",
+			Operation: &openapi.Operation{
+				OperationID: "cohorts",
+				Summary:     "Retention of every freshman cohort",
+				Tags:        []string{"stats"},
+				Responses: map[string]*openapi.Response{
+					"200": {Description: "The cohorts, oldest first", Content: openapi.JSONContent(openapi.Ref("Cohorts"))},
+					"429": tooManyRequests,
+				},
+			},
+		},
+		{
+			Method: "GET",
+			Path:   "/api/v1/alumni",
+			Operation: &openapi.Operation{
+				OperationID: "alumniDirectory",
+				Summary:     "Where are they now: a directory of alumni",
+				Description: "Only what alumni have agreed to share is listed, or taken into account when filtering.",
+				Tags:        []string{"purdoobah"},
+				Parameters:  directoryParameters,
+				Responses: map[string]*openapi.Response{
+					"200": {Description: "The matching alumni, sorted by name", Content: openapi.JSONContent(openapi.Ref("Directory"))},
+					"400": errorResponse("The graduation year isn't a number"),
+					"429": tooManyRequests,
+				},
+			},
+		},
+		{
+			Method: "GET",
+			Path:   "/api/v1/map",
+			Operation: &openapi.Operation{
+				OperationID: "hometownMap",
+				Summary:     "Locates the hometowns of a section",
+				Description: "Hometowns are located with an offline gazetteer; unknown or unresolvable hometowns are left out.",
+				Tags:        []string{"section"},
+				Parameters:  []openapi.Parameter{mapYearParameter},
+				Responses: map[string]*openapi.Response{
+					"200": {
+						Description: "The hometowns",
+						Content: map[string]*openapi.MediaType{
+							mimetype.GeoJson.String(): {Schema: openapi.Ref("Hometowns")},
+						},
+					},
+					"404": errorResponse("No section exists for that year"),
+					"429": tooManyRequests,
+				},
+			},
+		},
+		{
+			Method: "GET",
+			Path:   "/api/v1/leaders",
+			Operation: &openapi.Operation{
+				OperationID: "leaders",
+				Summary:     "Lists the section's leaders year by year",
+				Description: "Each leader's term is the whole consecutive run of years they held the role.",
+				Tags:        []string{"section"},
+				Responses: map[string]*openapi.Response{
+					"200": {Description: "The leaders", Content: openapi.JSONContent(openapi.Ref("Leaders"))},
+					"429": tooManyRequests,
+				},
+			},
+		},
+		{
+			Method: "GET",
+			Path:   "/api/v1/cravers/all",
+			Operation: &openapi.Operation{
+				OperationID: "allCravers",
+				Summary:     "Lists every inductee of the Cravers Hall of Fame",
+				Tags:        []string{"cravers"},
+				Responses: map[string]*openapi.Response{
+					"200": {
+						Description: "Every Craver, most recently inducted first",
+						Content:     openapi.JSONContent(openapi.ArrayOf(openapi.Ref("Craver"))),
+					},
+					"429": tooManyRequests,
+				},
+			},
+		},
+		{
+			Method: "GET",
+			Path:   "/api/v1/cravers/{name}",
+			Operation: &openapi.Operation{
+				OperationID: "craverByName",
+				Summary:     "Gets a single inductee of the Cravers Hall of Fame",
+				Tags:        []string{"cravers"},
+				Parameters:  []openapi.Parameter{craverNameParameter},
+				Responses: map[string]*openapi.Response{
+					"200": {Description: "The Craver", Content: openapi.JSONContent(openapi.Ref("Craver"))},
+					"404": errorResponse("No Craver exists with that name"),
+					"429": tooManyRequests,
+				},
+			},
+		},
+		{
+			Method: "GET",
+			Path:   "/api/v1/achievements/all",
+			Operation: &openapi.Operation{
+				OperationID: "allAchievements",
+				Summary:     "Lists every achievement in the registry",
+				Tags:        []string{"achievements"},
+				Responses: map[string]*openapi.Response{
+					"200": {
+						Description: "Every achievement, sorted by name",
+						Content:     openapi.JSONContent(openapi.ArrayOf(openapi.Ref("Achievement"))),
+					},
+					"429": tooManyRequests,
+				},
+			},
+		},
+		{
+			Method: "GET",
+			Path:   "/api/v1/achievements/{id}",
+			Operation: &openapi.Operation{
+				OperationID: "achievementByID",
+				Summary:     "Gets a single achievement, along with every Purdoobah holding it",
+				Tags:        []string{"achievements"},
+				Parameters:  []openapi.Parameter{achievementIDParameter},
+				Responses: map[string]*openapi.Response{
+					"200": {Description: "The achievement and its holders", Content: openapi.JSONContent(openapi.Ref("AchievementHolders"))},
+					"404": errorResponse("No achievement exists with that ID"),
+					"429": tooManyRequests,
+				},
+			},
+		},
+		{
+			Method: "GET",
+			Path:   "/api/v1/events/all",
+			Operation: &openapi.Operation{
+				OperationID: "allEvents",
+				Summary:     "Lists every event on the section's calendar",
+				Description: "The same events are available as an iCalendar feed at /calendar.ics.",
+				Tags:        []string{"events"},
+				Responses: map[string]*openapi.Response{
+					"200": {
+						Description: "Every event, earliest first",
+						Content:     openapi.JSONContent(openapi.ArrayOf(openapi.Ref("Event"))),
+					},
+					"429": tooManyRequests,
+				},
+			},
+		},
+		{
+			Method: "GET",
+			Path:   "/api/v1/events/{name}",
+			Operation: &openapi.Operation{
+				OperationID: "eventByName",
+				Summary:     "Gets a single event",
+				Tags:        []string{"events"},
+				Parameters:  []openapi.Parameter{eventNameParameter},
+				Responses: map[string]*openapi.Response{
+					"200": {Description: "The event", Content: openapi.JSONContent(openapi.Ref("Event"))},
+					"404": errorResponse("No event exists with that name"),
+					"429": tooManyRequests,
+				},
+			},
+		},
+		{
+			Method: "GET",
+			Path:   "/api/v1/toobahsassins",
+			Operation: &openapi.Operation{
+				OperationID: "allToobahsassinsGames",
+				Summary:     "Lists every game of Toobahsassins",
+				Tags:        []string{"toobahsassins"},
+				Responses: map[string]*openapi.Response{
+					"200": {
+						Description: "Every game, newest first",
+						Content:     openapi.JSONContent(openapi.ArrayOf(openapi.Ref("Game"))),
+					},
+					"429": tooManyRequests,
+				},
+			},
+		},
+		{
+			Method: "GET",
+			Path:   "/api/v1/toobahsassins/{id}",
+			Operation: &openapi.Operation{
+				OperationID: "toobahsassinsGameByID",
+				Summary:     "Gets the leaderboard of a single game of Toobahsassins",
+				Tags:        []string{"toobahsassins"},
+				Parameters:  []openapi.Parameter{gameIDParameter},
+				Responses: map[string]*openapi.Response{
+					"200": {Description: "The game", Content: openapi.JSONContent(openapi.Ref("Game"))},
+					"404": errorResponse("No game exists with that ID"),
+					"429": tooManyRequests,
+				},
+			},
+		},
+		{
+			Method: "GET",
+			Path:   "/api/v1/tradition/all",
+			Operation: &openapi.Operation{
+				OperationID: "allTraditions",
+				Summary:     "Lists every tradition",
+				Tags:        []string{"tradition"},
+				Responses: map[string]*openapi.Response{
+					"200": {
+						Description: "Every tradition, sorted by name",
+						Content:     openapi.JSONContent(openapi.ArrayOf(openapi.Ref("Tradition"))),
+					},
+					"429": tooManyRequests,
+				},
+			},
+		},
+		{
+			Method: "GET",
+			Path:   "/api/v1/tradition/{name}",
+			Operation: &openapi.Operation{
+				OperationID: "traditionByName",
+				Summary:     "Gets a single tradition",
+				Tags:        []string{"tradition"},
+				Parameters:  []openapi.Parameter{traditionNameParameter},
+				Responses: map[string]*openapi.Response{
+					"200": {Description: "The tradition", Content: openapi.JSONContent(openapi.Ref("Tradition"))},
+					"404": errorResponse("No tradition exists with that name"),
+					"429": tooManyRequests,
+				},
+			},
+		},
+	}
+}
+
+// validateOpenAPIDocument makes sure every API route is documented, and every documented operation has a route.
+func (app *application) validateOpenAPIDocument(doc *openapi.Document) error {
+	documented := make(map[string]bool)
+	for _, endpoint := range doc.Endpoints() {
+		documented[fmt.Sprintf("%s %s", endpoint.Method, endpoint.Path)] = true
+	}
+
+	routed := make(map[string]bool)
+	err := app.router.Walk(func(route *mux.Route, router *mux.Router, ancestors []*mux.Route) error {
+		pathTemplate, err := route.GetPathTemplate()
+		if err != nil || !strings.HasPrefix(pathTemplate, openAPIRoutePrefix) {
+			return nil
+		}
+
+		// subrouters don't have methods
+		methods, err := route.GetMethods()
+		if err != nil {
+			return nil
+		}
+
+		for _, method := range methods {
+			routed[fmt.Sprintf("%s %s", method, pathTemplate)] = true
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	var problems []string
+	for operation := range routed {
+		if !documented[operation] {
+			problems = append(problems, fmt.Sprintf("route isn't documented in the OpenAPI document: `%s`", operation))
+		}
+	}
+	for operation := range documented {
+		if !routed[operation] {
+			problems = append(problems, fmt.Sprintf("OpenAPI document describes a route that doesn't exist: `%s`", operation))
+		}
+	}
+	if len(problems) > 0 {
+		sort.Strings(problems)
+		return fmt.Errorf("invalid OpenAPI document:\n%s", strings.Join(problems, "\n"))
+	}
+
+	return nil
+}
+
+func (app *application) apiOpenAPIDocument(w http.ResponseWriter, r *http.Request) {
+	w.Header().Add(
+		httpheader.ContentType.String(),
+		fmt.Sprintf("%s; charset=utf-8", mimetype.Json.String()),
+	)
+	_, err := w.Write(app.openAPIDocumentJSON)
+	if err != nil {
+		app.serveError(w, r, err)
+		return
+	}
+}
+
+func (app *application) pageAPIDocs(w http.ResponseWriter, r *http.Request) {
+	app.render(w, r, "api-docs.gohtml", &templateData{
+		Page: page{
+			DisplayName: "API",
+			URL:         "/api/docs",
+			Scripts:     []string{app.cacheBuster.Get("/static/script/api-docs.js")},
+		},
+		OpenAPI: app.openAPIDocument,
+		Metadata: metadata{
+			Description: "Documentation of the public Purdoobahs JSON API.",
+		},
+	})
+}
@@ -3,36 +3,65 @@ package main
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"html/template"
 	"io/ioutil"
 	"net/http"
-	"runtime/debug"
 	"strconv"
-
+	"strings"
+	"time"
+
+	"github.com/purdoobahs/purdoobahs.com/internal/achievements"
+	"github.com/purdoobahs/purdoobahs.com/internal/alumni"
+	"github.com/purdoobahs/purdoobahs.com/internal/apierror"
+	"github.com/purdoobahs/purdoobahs.com/internal/cravers"
+	"github.com/purdoobahs/purdoobahs.com/internal/csrf"
+	"github.com/purdoobahs/purdoobahs.com/internal/events"
+	"github.com/purdoobahs/purdoobahs.com/internal/export"
+	"github.com/purdoobahs/purdoobahs.com/internal/graphqlapi"
 	"github.com/purdoobahs/purdoobahs.com/internal/httpheader"
-	"github.com/purdoobahs/purdoobahs.com/internal/logger"
+	"github.com/purdoobahs/purdoobahs.com/internal/imageresize"
+	"github.com/purdoobahs/purdoobahs.com/internal/jsonld"
+	"github.com/purdoobahs/purdoobahs.com/internal/leaders"
 	"github.com/purdoobahs/purdoobahs.com/internal/mimetype"
 	"github.com/purdoobahs/purdoobahs.com/internal/plausibleanalytics"
+	"github.com/purdoobahs/purdoobahs.com/internal/purdoobahs"
+	"github.com/purdoobahs/purdoobahs.com/internal/requestid"
+	"github.com/purdoobahs/purdoobahs.com/internal/rsvp"
+	"github.com/purdoobahs/purdoobahs.com/internal/sectionphotos"
+	"github.com/purdoobahs/purdoobahs.com/internal/spamguard"
+	"github.com/purdoobahs/purdoobahs.com/internal/stats"
+	"github.com/purdoobahs/purdoobahs.com/internal/toobahsassins"
+	"github.com/purdoobahs/purdoobahs.com/internal/traditions"
+	"github.com/purdoobahs/purdoobahs.com/internal/trustedproxy"
 
-	"github.com/gorilla/handlers"
 	"github.com/gorilla/mux"
 	"github.com/justinas/alice"
 )
 
 func (app *application) routes() http.Handler {
 	standardMiddleware := alice.New(
+		requestid.RequestID,
 		app.recoverPanic,
-		handlers.ProxyHeaders,
+		app.trustedProxy.RealIP,
 		app.logRequest,
 		app.helmet.Secure,
 		app.cacheControl.ForeverCache,
+		app.cors.Handler,
+		app.rateLimiter.Limit,
+		app.csrf.Protect,
 	)
 
 	// routers
 	router := mux.NewRouter()
+	router.MethodNotAllowedHandler = http.HandlerFunc(app.methodNotAllowed)
+	app.router = router
 	staticFilesSubrouter := router.PathPrefix("/static").Subrouter()
 	apiSubrouter := router.PathPrefix("/api").Subrouter()
 	apiV1Subrouter := apiSubrouter.PathPrefix("/v1").Subrouter()
+	adminSubrouter := router.PathPrefix("/admin").Subrouter()
+	adminSubrouter.Use(app.requireAdmin)
 
 	// files
 	router.HandleFunc("/favicon.ico", app.fileFavicon).Methods("GET")
@@ -43,14 +72,42 @@ func (app *application) routes() http.Handler {
 	router.HandleFunc("/tradition/sitemap.xml", app.fileTraditionsSitemapXml).Methods("GET")
 	router.HandleFunc("/robots.txt", app.fileRobotsTxt).Methods("GET")
 	router.HandleFunc("/humans.txt", app.fileHumansTxt).Methods("GET")
+	router.HandleFunc("/calendar.ics", app.fileCalendarIcs).Methods("GET")
+	router.HandleFunc("/image", app.fileImage).Methods("GET")
+	router.HandleFunc("/social-card/{kind:purdoobah|section|tradition}/{id:[^/.]+}.{hash:[0-9a-f]{32}}.jpg", app.fileSocialCard).Methods("GET")
 
 	// pages
 	router.HandleFunc("/cravers-hall-of-fame", app.pageCraversHallOfFame).Methods("GET")
+	router.HandleFunc("/cravers-hall-of-fame/apply", app.pageCraversApplication).Methods("GET")
+	router.HandleFunc("/cravers-hall-of-fame/apply", app.submitCraversApplication).Methods("POST")
 	router.HandleFunc("/tradition", app.pageTradition).Methods("GET")
 	router.HandleFunc("/tradition/{name}", app.pageTraditionProfile).Methods("GET")
 	router.HandleFunc("/alumni", app.pageAlumni).Methods("GET")
+	router.HandleFunc("/stats", app.pageStats).Methods("GET")
+	router.HandleFunc("/stats/cohorts", app.pageCohorts).Methods("GET")
+	router.HandleFunc("/map", app.pageMap).Methods("GET")
+	router.HandleFunc("/leaders", app.pageLeaders).Methods("GET")
+	router.HandleFunc("/events", app.pageEvents).Methods("GET")
+	router.HandleFunc("/events/{name}", app.pageEvent).Methods("GET")
+	router.HandleFunc("/events/{name}/rsvp", app.submitRSVP).Methods("POST")
+	router.HandleFunc("/toobahsassins", app.pageToobahsassins).Methods("GET")
+	router.HandleFunc("/toobahsassins/{id}", app.pageToobahsassinsGame).Methods("GET")
 	router.HandleFunc("/section/{year}", app.pageSectionByYear).Methods("GET")
 	router.HandleFunc("/purdoobah/{name}", app.pagePurdoobahProfile).Methods("GET")
+	router.HandleFunc("/gallery", app.pageGallery).Methods("GET")
+	router.HandleFunc("/section/{year}/gallery", app.pageSectionGallery).Methods("GET")
+	router.HandleFunc("/tradition/{name}/gallery", app.pageTraditionGallery).Methods("GET")
+	router.HandleFunc("/purdoobah/{name}/gallery", app.pagePurdoobahGallery).Methods("GET")
+
+	// admin
+	adminSubrouter.HandleFunc("/cravers", app.pageAdminCravers).Methods("GET")
+	adminSubrouter.HandleFunc("/cravers/applications/{id}", app.adminReviewCraversApplication).Methods("POST")
+	adminSubrouter.HandleFunc("/events", app.pageAdminEvents).Methods("GET")
+	adminSubrouter.HandleFunc("/events/{name}/rsvps.csv", app.adminExportRSVPs).Methods("GET")
+	adminSubrouter.HandleFunc("/events/{name}/rsvps/{id}/cancel", app.adminCancelRSVP).Methods("POST")
+	adminSubrouter.HandleFunc("/toobahsassins", app.pageAdminToobahsassins).Methods("GET")
+	adminSubrouter.HandleFunc("/toobahsassins", app.adminCreateToobahsassinsGame).Methods("POST")
+	adminSubrouter.HandleFunc("/toobahsassins/{id}/eliminations", app.adminEliminateToobahsassinsTarget).Methods("POST")
 
 	// static files
 	staticFilesSubrouter.PathPrefix("/").Handler(
@@ -61,8 +118,47 @@ func (app *application) routes() http.Handler {
 	// has to occur last because it is the most generic route "/"
 	router.PathPrefix("/").HandlerFunc(app.pageHome).Methods("GET")
 
+	// API documentation
+	apiSubrouter.HandleFunc("/docs", app.pageAPIDocs).Methods("GET")
+
 	// generic API
 	apiV1Subrouter.HandleFunc("/health", app.apiHealthCheck).Methods("GET")
+	apiV1Subrouter.HandleFunc("/openapi.json", app.apiOpenAPIDocument).Methods("GET")
+
+	// GraphQL API
+	apiSubrouter.HandleFunc("/graphql", app.apiGraphQL).Methods("GET", "POST")
+
+	// statistics API
+	apiV1Subrouter.HandleFunc("/stats", app.apiStats).Methods("GET")
+	apiV1Subrouter.HandleFunc("/stats/cohorts", app.apiCohorts).Methods("GET")
+
+	// alumni API
+	apiV1Subrouter.HandleFunc("/alumni", app.apiAlumni).Methods("GET")
+
+	// leaders API
+	apiV1Subrouter.HandleFunc("/leaders", app.apiLeaders).Methods("GET")
+
+	// map API
+	apiV1Subrouter.HandleFunc("/map", app.apiMap).Methods("GET")
+
+	// Cravers Hall of Fame API
+	apiV1CraversSubrouter := apiV1Subrouter.PathPrefix("/cravers").Subrouter()
+	apiV1CraversSubrouter.HandleFunc("/all", app.apiAllCravers).Methods("GET")
+	apiV1CraversSubrouter.HandleFunc("/{name}", app.apiCraverByName).Methods("GET")
+
+	// achievements API
+	apiV1AchievementsSubrouter := apiV1Subrouter.PathPrefix("/achievements").Subrouter()
+	apiV1AchievementsSubrouter.HandleFunc("/all", app.apiAllAchievements).Methods("GET")
+	apiV1AchievementsSubrouter.HandleFunc("/{id}", app.apiAchievementByID).Methods("GET")
+
+	// events API
+	apiV1EventsSubrouter := apiV1Subrouter.PathPrefix("/events").Subrouter()
+	apiV1EventsSubrouter.HandleFunc("/all", app.apiAllEvents).Methods("GET")
+	apiV1EventsSubrouter.HandleFunc("/{name}", app.apiEventByName).Methods("GET")
+
+	// Toobahsassins API
+	apiV1Subrouter.HandleFunc("/toobahsassins", app.apiAllToobahsassinsGames).Methods("GET")
+	apiV1Subrouter.HandleFunc("/toobahsassins/{id}", app.apiToobahsassinsGameByID).Methods("GET")
 
 	// analytics API
 	apiV1Subrouter.HandleFunc("/scitylana", app.apiAnalytics).Methods("POST")
@@ -80,6 +176,7 @@ func (app *application) routes() http.Handler {
 	// tradition API
 	apiV1TraditionSubrouter := apiV1Subrouter.PathPrefix("/tradition").Subrouter()
 	apiV1TraditionSubrouter.HandleFunc("/all", app.apiAllTraditions).Methods("GET")
+	apiV1TraditionSubrouter.HandleFunc("/{name}", app.apiTraditionByName).Methods("GET")
 
 	// api catch all
 	// has to occur last because it is the most generic route "/api/" and "/api/v1/"
@@ -98,7 +195,7 @@ func (app *application) pageHome(w http.ResponseWriter, r *http.Request) {
 	// get current section
 	currentSection, err := app.purdoobahService.CurrentSection()
 	if err != nil {
-		app.serveError(w, err)
+		app.serveError(w, r, err)
 		return
 	}
 
@@ -112,23 +209,152 @@ func (app *application) pageHome(w http.ResponseWriter, r *http.Request) {
 }
 
 func (app *application) pageCraversHallOfFame(w http.ResponseWriter, r *http.Request) {
+	// get all cravers
+	allCravers, err := app.craverService.All()
+	if err != nil {
+		app.serveError(w, r, err)
+		return
+	}
+
 	app.render(w, r, "cravers-hall-of-fame.gohtml", &templateData{
 		Page: page{
 			DisplayName: "Cravers Hall of Fame",
 			URL:         "/cravers-hall-of-fame",
 		},
+		Cravers: allCravers,
+		Metadata: metadata{
+			SocialImage: app.cacheBuster.Get("/static/image/socials/cravers-hall-of-fame.webp"),
+			Description: "Inductees of the White Castle Cravers Hall of Fame!",
+		},
+	})
+}
+
+func (app *application) pageCraversApplication(w http.ResponseWriter, r *http.Request) {
+	app.renderCraversApplication(w, r, &form{
+		Values:    map[string]string{},
+		Errors:    map[string]string{},
+		Submitted: r.URL.Query().Get("submitted") != "",
+	})
+}
+
+func (app *application) submitCraversApplication(w http.ResponseWriter, r *http.Request) {
+	err := r.ParseForm()
+	if err != nil {
+		app.clientError(w, r, http.StatusBadRequest)
+		return
+	}
+
+	application := &cravers.Application{
+		Name:      r.PostForm.Get("name"),
+		Email:     r.PostForm.Get("email"),
+		Nominee:   r.PostForm.Get("nominee"),
+		Purdoobah: r.PostForm.Get("purdoobah"),
+		Story:     r.PostForm.Get("story"),
+	}
+
+	// point out everything that needs fixing, keeping what they've already written
+	problems := application.Validate()
+	if application.Purdoobah != "" {
+		if _, err := app.purdoobahService.ByName(application.Purdoobah); err != nil {
+			problems["purdoobah"] = "Please pick a Purdoobah from the list."
+		}
+	}
+	if len(problems) > 0 {
+		app.renderCraversApplication(w, r, &form{
+			Values: map[string]string{
+				"name":      application.Name,
+				"email":     application.Email,
+				"nominee":   application.Nominee,
+				"purdoobah": application.Purdoobah,
+				"story":     application.Story,
+			},
+			Errors: problems,
+		})
+		return
+	}
+
+	// add it to the review queue
+	application, err = app.applicationService.Submit(application)
+	if err != nil {
+		app.serveError(w, r, err)
+		return
+	}
+
+	app.logger.Info(fmt.Sprintf("Received Cravers Hall of Fame application `%s`", application.ID))
+	http.Redirect(w, r, "/cravers-hall-of-fame/apply?submitted=true", http.StatusSeeOther)
+}
+
+func (app *application) renderCraversApplication(w http.ResponseWriter, r *http.Request, f *form) {
+	// get all purdoobahs, for picking the nominee
+	allPurdoobahs, err := app.purdoobahService.All()
+	if err != nil {
+		app.serveError(w, r, err)
+		return
+	}
+
+	app.render(w, r, "cravers-application.gohtml", &templateData{
+		Page: page{
+			DisplayName: "Cravers Hall of Fame Application",
+			URL:         "/cravers-hall-of-fame/apply",
+		},
+		Purdoobahs: allPurdoobahs,
+		Form:       f,
+		CSRFToken:  csrf.Token(r),
 		Metadata: metadata{
 			SocialImage: app.cacheBuster.Get("/static/image/socials/cravers-hall-of-fame.webp"),
-			Description: "Inductees of the 2019 White Castle Cravers Hall of Fame!",
+			Description: "Nominate someone for the White Castle Cravers Hall of Fame.",
 		},
 	})
 }
 
+func (app *application) pageAdminCravers(w http.ResponseWriter, r *http.Request) {
+	// get all applications
+	applications, err := app.applicationService.All()
+	if err != nil {
+		app.serveError(w, r, err)
+		return
+	}
+
+	app.render(w, r, "admin-cravers.gohtml", &templateData{
+		Page: page{
+			DisplayName: "Cravers Hall of Fame Admin",
+			URL:         "/admin/cravers",
+		},
+		Applications: applications,
+		CSRFToken:    csrf.Token(r),
+	})
+}
+
+func (app *application) adminReviewCraversApplication(w http.ResponseWriter, r *http.Request) {
+	// get id
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	// get the outcome
+	status := cravers.ApplicationStatus(r.PostFormValue("status"))
+	if !cravers.IsValidStatus(status) {
+		app.clientError(w, r, http.StatusBadRequest)
+		return
+	}
+
+	// record it
+	_, err := app.applicationService.Review(id, status)
+	if errors.Is(err, cravers.ErrApplicationNotFound) {
+		app.pageNotFound(w, r)
+		return
+	} else if err != nil {
+		app.serveError(w, r, err)
+		return
+	}
+
+	http.Redirect(w, r, "/admin/cravers", http.StatusSeeOther)
+}
+
 func (app *application) pageTradition(w http.ResponseWriter, r *http.Request) {
 	// get all traditions
 	allTraditions, err := app.traditionService.All()
 	if err != nil {
-		app.serveError(w, err)
+		app.serveError(w, r, err)
 		return
 	}
 
@@ -146,14 +372,31 @@ func (app *application) pageTradition(w http.ResponseWriter, r *http.Request) {
 }
 
 func (app *application) pageTraditionProfile(w http.ResponseWriter, r *http.Request) {
+	format := app.negotiatePage(w, r)
+
 	// get name
 	vars := mux.Vars(r)
 	name := vars["name"]
 
 	// get tradition
 	traditionByName, err := app.traditionService.ByName(name)
+	if errors.Is(err, traditions.ErrTraditionNotFound) {
+		app.pageNotFoundAs(w, r, format, err)
+		return
+	} else if err != nil {
+		app.serveError(w, r, err)
+		return
+	}
+
+	if format != pageFormatHTML {
+		app.writePageData(w, r, format, traditionByName, jsonld.NewTradition(traditionByName, baseURL))
+		return
+	}
+
+	// get its photos
+	photos, err := app.photoService.ByTradition(traditionByName.ID)
 	if err != nil {
-		app.pageNotFound(w, r)
+		app.serveError(w, r, err)
 		return
 	}
 
@@ -163,22 +406,47 @@ func (app *application) pageTraditionProfile(w http.ResponseWriter, r *http.Requ
 			URL:         fmt.Sprintf("/tradition/%s", name),
 		},
 		TraditionByName: traditionByName,
+		Gallery:         app.newGalleryView(traditionByName.Name, fmt.Sprintf("/tradition/%s", name), photos),
 		Metadata: metadata{
-			SocialImage: traditionByName.Metadata.Image.File,
+			SocialCard:  app.newTraditionSocialCard(traditionByName),
 			Description: traditionByName.Description,
 		},
 	})
 }
 
 func (app *application) pagePurdoobahProfile(w http.ResponseWriter, r *http.Request) {
+	format := app.negotiatePage(w, r)
+
 	// get name
 	vars := mux.Vars(r)
 	name := vars["name"]
 
 	// get purdoobah
 	purdoobahByName, err := app.purdoobahService.ByName(name)
+	if errors.Is(err, purdoobahs.ErrPurdoobahNotFound) {
+		app.pageNotFoundAs(w, r, format, err)
+		return
+	} else if err != nil {
+		app.serveError(w, r, err)
+		return
+	}
+
+	if format != pageFormatHTML {
+		app.writePageData(w, r, format, purdoobahByName, jsonld.NewPerson(purdoobahByName, baseURL))
+		return
+	}
+
+	// get the badges of their achievements
+	allAchievements, err := app.achievementService.All()
 	if err != nil {
-		app.pageNotFound(w, r)
+		app.serveError(w, r, err)
+		return
+	}
+
+	// get the photos they're in
+	photos, err := app.photoService.ByPurdoobah(purdoobahByName.ID)
+	if err != nil {
+		app.serveError(w, r, err)
 		return
 	}
 
@@ -188,8 +456,10 @@ func (app *application) pagePurdoobahProfile(w http.ResponseWriter, r *http.Requ
 			URL:         fmt.Sprintf("/purdoobah/%s", name),
 		},
 		PurdoobahByName: purdoobahByName,
+		Badges:          achievements.Badges(allAchievements, purdoobahByName),
+		Gallery:         app.newGalleryView(purdoobahByName.Name, fmt.Sprintf("/purdoobah/%s", name), photos),
 		Metadata: metadata{
-			SocialImage: purdoobahByName.Metadata.Image.File,
+			SocialCard: app.newPurdoobahSocialCard(purdoobahByName),
 			Description: fmt.Sprintf(
 				"Meet %s! %s Member of the %s Purdoobah section(s).",
 				purdoobahByName.Name,
@@ -200,18 +470,171 @@ func (app *application) pagePurdoobahProfile(w http.ResponseWriter, r *http.Requ
 	})
 }
 
+func (app *application) pageGallery(w http.ResponseWriter, r *http.Request) {
+	// get all photos
+	allPhotos, err := app.photoService.All()
+	if err != nil {
+		app.serveError(w, r, err)
+		return
+	}
+
+	app.renderGallery(w, r, "/gallery", app.newGalleryView("", "", allPhotos))
+}
+
+func (app *application) pageSectionGallery(w http.ResponseWriter, r *http.Request) {
+	// get year
+	vars := mux.Vars(r)
+	yearAsString := vars["year"]
+
+	// convert from string to int
+	yearAsInt, err := strconv.Atoi(yearAsString)
+	if err != nil {
+		app.pageNotFound(w, r)
+		return
+	}
+
+	// the section has to exist
+	_, err = app.purdoobahService.SectionByYear(yearAsInt)
+	if errors.Is(err, purdoobahs.ErrSectionNotFound) {
+		app.pageNotFound(w, r)
+		return
+	} else if err != nil {
+		app.serveError(w, r, err)
+		return
+	}
+
+	// get its photos
+	photos, err := app.photoService.ByYear(yearAsInt)
+	if err != nil {
+		app.serveError(w, r, err)
+		return
+	}
+
+	app.renderGallery(w, r, fmt.Sprintf("/section/%d/gallery", yearAsInt), app.newGalleryView(
+		fmt.Sprintf("%d Section", yearAsInt),
+		fmt.Sprintf("/section/%d", yearAsInt),
+		photos,
+	))
+}
+
+func (app *application) pageTraditionGallery(w http.ResponseWriter, r *http.Request) {
+	// get name
+	vars := mux.Vars(r)
+	name := vars["name"]
+
+	// get tradition
+	traditionByName, err := app.traditionService.ByName(name)
+	if errors.Is(err, traditions.ErrTraditionNotFound) {
+		app.pageNotFound(w, r)
+		return
+	} else if err != nil {
+		app.serveError(w, r, err)
+		return
+	}
+
+	// get its photos
+	photos, err := app.photoService.ByTradition(traditionByName.ID)
+	if err != nil {
+		app.serveError(w, r, err)
+		return
+	}
+
+	app.renderGallery(w, r, fmt.Sprintf("/tradition/%s/gallery", name), app.newGalleryView(
+		traditionByName.Name,
+		fmt.Sprintf("/tradition/%s", name),
+		photos,
+	))
+}
+
+func (app *application) pagePurdoobahGallery(w http.ResponseWriter, r *http.Request) {
+	// get name
+	vars := mux.Vars(r)
+	name := vars["name"]
+
+	// get purdoobah
+	purdoobahByName, err := app.purdoobahService.ByName(name)
+	if errors.Is(err, purdoobahs.ErrPurdoobahNotFound) {
+		app.pageNotFound(w, r)
+		return
+	} else if err != nil {
+		app.serveError(w, r, err)
+		return
+	}
+
+	// get the photos they're in
+	photos, err := app.photoService.ByPurdoobah(purdoobahByName.ID)
+	if err != nil {
+		app.serveError(w, r, err)
+		return
+	}
+
+	app.renderGallery(w, r, fmt.Sprintf("/purdoobah/%s/gallery", name), app.newGalleryView(
+		purdoobahByName.Name,
+		fmt.Sprintf("/purdoobah/%s", name),
+		photos,
+	))
+}
+
+func (app *application) renderGallery(w http.ResponseWriter, r *http.Request, url string, view *galleryView) {
+	// the first photo stands in for the whole gallery when it's shared
+	var socialImage string
+	if len(view.Photos) > 0 {
+		socialImage = view.Photos[0].Photo.Metadata.Image.File
+	}
+
+	displayName := "Photos"
+	description := "Photos of the Purdoobahs, from every section."
+	if view.Title != "" {
+		displayName = fmt.Sprintf("%s Photos", view.Title)
+		description = fmt.Sprintf("Photos of the Purdoobahs: %s.", view.Title)
+	}
+
+	app.render(w, r, "gallery.gohtml", &templateData{
+		Page: page{
+			DisplayName: displayName,
+			URL:         url,
+		},
+		Gallery: view,
+		Metadata: metadata{
+			SocialImage: socialImage,
+			Description: description,
+		},
+	})
+}
+
 func (app *application) pageAlumni(w http.ResponseWriter, r *http.Request) {
-	// get all purdoobahs
-	allPurdoobahs, err := app.purdoobahService.All()
+	// get the directory's filter
+	filter, err := alumniFilter(r)
 	if err != nil {
-		app.serveError(w, err)
+		app.clientError(w, r, http.StatusBadRequest)
 		return
 	}
 
+	// get the alumni directory
+	directory, err := alumni.NewDirectory(app.purdoobahService, filter)
+	if err != nil {
+		app.serveError(w, r, err)
+		return
+	}
+
+	// get all purdoobahs, unless the directory is filtered
+	var listedPurdoobahs []*purdoobahs.Purdoobah
+	if filter.IsEmpty() {
+		listedPurdoobahs, err = app.purdoobahService.All()
+		if err != nil {
+			app.serveError(w, r, err)
+			return
+		}
+	} else {
+		for _, entry := range directory.Alumni {
+			listedPurdoobahs = append(listedPurdoobahs, entry.Purdoobah)
+		}
+	}
+
 	// get all years marched
 	allYearsMarched, err := app.purdoobahService.AllSectionYears()
 	if err != nil {
-		app.serveError(w, err)
+		app.serveError(w, r, err)
 		return
 	}
 
@@ -221,8 +644,9 @@ func (app *application) pageAlumni(w http.ResponseWriter, r *http.Request) {
 			URL:         "/alumni",
 			Scripts:     []string{app.cacheBuster.Get("/static/script/alumni.js")},
 		},
-		Purdoobahs:      allPurdoobahs,
+		Purdoobahs:      listedPurdoobahs,
 		AllYearsMarched: allYearsMarched,
+		Directory:       directory,
 		Metadata: metadata{
 			SocialImage: app.cacheBuster.Get("/static/image/section/2019.webp"),
 			Description: "OOOOOOOOOOOOOOOOOOLLLLDDDDDD",
@@ -230,7 +654,403 @@ func (app *application) pageAlumni(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+func (app *application) pageStats(w http.ResponseWriter, r *http.Request) {
+	// compute statistics
+	sectionStats, err := stats.Compute(app.purdoobahService)
+	if err != nil {
+		app.serveError(w, r, err)
+		return
+	}
+
+	app.render(w, r, "stats.gohtml", &templateData{
+		Page: page{
+			DisplayName: "Statistics",
+			URL:         "/stats",
+		},
+		Stats:  sectionStats,
+		Charts: statsCharts(sectionStats),
+		Metadata: metadata{
+			Description: "The Purdoobah section by the numbers.",
+		},
+	})
+}
+
+func (app *application) pageCohorts(w http.ResponseWriter, r *http.Request) {
+	// compute cohorts
+	cohorts, err := stats.ComputeCohorts(app.purdoobahService)
+	if err != nil {
+		app.serveError(w, r, err)
+		return
+	}
+
+	app.render(w, r, "cohorts.gohtml", &templateData{
+		Page: page{
+			DisplayName: "Cohort Retention",
+			URL:         "/stats/cohorts",
+		},
+		Cohorts: cohorts,
+		Charts:  map[string]template.HTML{"retention": cohortsChart(cohorts)},
+		Metadata: metadata{
+			Description: "How many freshmen from each year came back as sophomores, juniors, and seniors.",
+		},
+	})
+}
+
+func (app *application) pageMap(w http.ResponseWriter, r *http.Request) {
+	// get the Purdoobahs of the requested section
+	members, year, err := app.mapPurdoobahs(r.URL.Query().Get("year"))
+	if errors.Is(err, purdoobahs.ErrSectionNotFound) {
+		app.clientError(w, r, http.StatusNotFound)
+		return
+	} else if err != nil {
+		app.serveError(w, r, err)
+		return
+	}
+
+	// get all section years, for switching between them
+	allYearsMarched, err := app.purdoobahService.AllSectionYears()
+	if err != nil {
+		app.serveError(w, r, err)
+		return
+	}
+
+	sectionName := "every Purdoobah"
+	if year != 0 {
+		sectionName = fmt.Sprintf("the %d section", year)
+	}
+
+	hometowns := app.groupHometowns(members)
+	app.render(w, r, "map.gohtml", &templateData{
+		Page: page{
+			DisplayName: "Map",
+			URL:         "/map",
+		},
+		Year:            year,
+		AllYearsMarched: allYearsMarched,
+		Hometowns:       hometowns,
+		Charts:          hometownMaps(hometowns, sectionName),
+		Metadata: metadata{
+			Description: fmt.Sprintf("Where %s calls home.", sectionName),
+		},
+	})
+}
+
+func (app *application) pageLeaders(w http.ResponseWriter, r *http.Request) {
+	// get the section's leaders, year by year
+	history, err := leaders.NewHistory(app.purdoobahService)
+	if err != nil {
+		app.serveError(w, r, err)
+		return
+	}
+
+	app.render(w, r, "leaders.gohtml", &templateData{
+		Page: page{
+			DisplayName: "Leaders",
+			URL:         "/leaders",
+		},
+		Leaders: history,
+		Metadata: metadata{
+			Description: "Every section leader, assistant section leader, and committee chair, year by year.",
+		},
+	})
+}
+
+func (app *application) pageEvents(w http.ResponseWriter, r *http.Request) {
+	// get all events
+	allEvents, err := app.eventService.All()
+	if err != nil {
+		app.serveError(w, r, err)
+		return
+	}
+
+	app.render(w, r, "events.gohtml", &templateData{
+		Page: page{
+			DisplayName: "Events",
+			URL:         "/events",
+		},
+		Events: app.newEventSchedule(allEvents, time.Now()),
+		Metadata: metadata{
+			Description: "Game days and other events of the Purdoobahs, with a calendar you can subscribe to.",
+		},
+	})
+}
+
+func (app *application) pageEvent(w http.ResponseWriter, r *http.Request) {
+	f := &form{
+		Values: map[string]string{},
+		Errors: map[string]string{},
+	}
+
+	// let them know how their RSVP went
+	status := rsvp.Status(r.URL.Query().Get("rsvp"))
+	if status == rsvp.Attending || status == rsvp.Waitlisted {
+		f.Submitted = true
+	}
+
+	app.renderEvent(w, r, f, status)
+}
+
+func (app *application) submitRSVP(w http.ResponseWriter, r *http.Request) {
+	// get event
+	e, ok := app.rsvpEvent(w, r)
+	if !ok {
+		return
+	}
+
+	err := r.ParseForm()
+	if err != nil {
+		app.clientError(w, r, http.StatusBadRequest)
+		return
+	}
+
+	submission := &rsvp.RSVP{
+		Event:     e.ID,
+		Name:      r.PostForm.Get("name"),
+		Purdoobah: r.PostForm.Get("purdoobah"),
+		Email:     r.PostForm.Get("email"),
+	}
+	values := map[string]string{
+		"name":      submission.Name,
+		"purdoobah": submission.Purdoobah,
+		"email":     submission.Email,
+		"guests":    r.PostForm.Get("guests"),
+	}
+
+	// bots are shown the same thing people are, so they don't learn what gave them away
+	err = app.spamGuard.Check(r, time.Now())
+	if errors.Is(err, spamguard.ErrHoneypot) {
+		app.logger.Info(fmt.Sprintf("Ignored an RSVP to `%s` that filled in the honeypot", e.ID))
+		http.Redirect(w, r, fmt.Sprintf("/events/%s?rsvp=%s", e.ID, rsvp.Attending), http.StatusSeeOther)
+		return
+	} else if err != nil {
+		app.renderEvent(w, r, &form{
+			Values: values,
+			Errors: map[string]string{"form": "This form expired or was sent too quickly; please try again."},
+		}, "")
+		return
+	}
+
+	// point out everything that needs fixing, keeping what they've already written
+	problems := make(map[string]string)
+	if guests := r.PostForm.Get("guests"); guests != "" {
+		submission.Guests, err = strconv.Atoi(guests)
+		if err != nil {
+			problems["guests"] = "Please enter a number."
+		}
+	}
+	for field, problem := range submission.Validate() {
+		problems[field] = problem
+	}
+	if submission.Purdoobah != "" {
+		if _, err := app.purdoobahService.ByName(submission.Purdoobah); err != nil {
+			problems["purdoobah"] = "Please pick a Purdoobah from the list."
+		}
+	}
+	if len(problems) > 0 {
+		app.renderEvent(w, r, &form{Values: values, Errors: problems}, "")
+		return
+	}
+
+	// add it to the end of the line, seating them if there's room
+	submission, err = app.rsvpService.Submit(submission, e.RSVP.Capacity)
+	if errors.Is(err, rsvp.ErrAlreadyRSVPed) {
+		app.renderEvent(w, r, &form{
+			Values: values,
+			Errors: map[string]string{"form": "You've already RSVPed to this event. Get in touch with us to change it."},
+		}, "")
+		return
+	} else if err != nil {
+		app.serveError(w, r, err)
+		return
+	}
+
+	app.logger.Info(fmt.Sprintf("Received RSVP `%s` to `%s` (%s)", submission.ID, e.ID, submission.Status))
+	http.Redirect(w, r, fmt.Sprintf("/events/%s?rsvp=%s", e.ID, submission.Status), http.StatusSeeOther)
+}
+
+// rsvpEvent gets the event being RSVPed to, writing an error response if it doesn't take RSVPs (anymore).
+func (app *application) rsvpEvent(w http.ResponseWriter, r *http.Request) (*events.Event, bool) {
+	// get name
+	vars := mux.Vars(r)
+	name := vars["name"]
+
+	// get event
+	e, err := app.eventService.ByName(name)
+	if errors.Is(err, events.ErrEventNotFound) || (err == nil && e.RSVP == nil) {
+		app.pageNotFound(w, r)
+		return nil, false
+	} else if err != nil {
+		app.serveError(w, r, err)
+		return nil, false
+	}
+
+	if !e.TakesRSVPs(time.Now()) {
+		app.clientError(w, r, http.StatusGone)
+		return nil, false
+	}
+
+	return e, true
+}
+
+func (app *application) renderEvent(w http.ResponseWriter, r *http.Request, f *form, status rsvp.Status) {
+	// get name
+	vars := mux.Vars(r)
+	name := vars["name"]
+
+	// get event
+	e, err := app.eventService.ByName(name)
+	if errors.Is(err, events.ErrEventNotFound) {
+		app.pageNotFound(w, r)
+		return
+	} else if err != nil {
+		app.serveError(w, r, err)
+		return
+	}
+
+	// count the seats
+	view, err := app.withRSVPs(app.newEventView(e))
+	if err != nil {
+		app.serveError(w, r, err)
+		return
+	}
+
+	// get all purdoobahs, for looking themselves up
+	allPurdoobahs, err := app.purdoobahService.All()
+	if err != nil {
+		app.serveError(w, r, err)
+		return
+	}
+
+	now := time.Now()
+	view.TakesRSVPs = e.TakesRSVPs(now)
+	description := fmt.Sprintf("%s on %s.", e.Name, e.Start.Format("Monday, January 2, 2006"))
+	if view.TakesRSVPs {
+		description += " RSVP to let us know you're coming!"
+	}
+
+	app.render(w, r, "event.gohtml", &templateData{
+		Page: page{
+			DisplayName: e.Name,
+			URL:         fmt.Sprintf("/events/%s", e.ID),
+		},
+		Event:      view,
+		Purdoobahs: allPurdoobahs,
+		Form:       f,
+		RSVPStatus: status,
+		CSRFToken:  csrf.Token(r),
+		FormToken:  app.spamGuard.Token(now),
+		Metadata: metadata{
+			Description: description,
+		},
+	})
+}
+
+func (app *application) pageAdminEvents(w http.ResponseWriter, r *http.Request) {
+	// get all events
+	allEvents, err := app.eventService.All()
+	if err != nil {
+		app.serveError(w, r, err)
+		return
+	}
+
+	// only events that take RSVPs have anything to manage
+	rsvpEvents := make([]*events.Event, 0)
+	for _, e := range allEvents {
+		if e.RSVP != nil {
+			rsvpEvents = append(rsvpEvents, e)
+		}
+	}
+
+	// count every event's seats
+	schedule := app.newEventSchedule(rsvpEvents, time.Now())
+	for _, views := range [][]*eventView{schedule.Upcoming, schedule.Past} {
+		for _, view := range views {
+			_, err = app.withRSVPs(view)
+			if err != nil {
+				app.serveError(w, r, err)
+				return
+			}
+		}
+	}
+
+	app.render(w, r, "admin-events.gohtml", &templateData{
+		Page: page{
+			DisplayName: "Events Admin",
+			URL:         "/admin/events",
+		},
+		Events:    schedule,
+		CSRFToken: csrf.Token(r),
+	})
+}
+
+func (app *application) adminExportRSVPs(w http.ResponseWriter, r *http.Request) {
+	// get name
+	vars := mux.Vars(r)
+	name := vars["name"]
+
+	// get event
+	e, err := app.eventService.ByName(name)
+	if errors.Is(err, events.ErrEventNotFound) {
+		app.pageNotFound(w, r)
+		return
+	} else if err != nil {
+		app.serveError(w, r, err)
+		return
+	}
+
+	// get its attendee list
+	view, err := app.withRSVPs(app.newEventView(e))
+	if err != nil {
+		app.serveError(w, r, err)
+		return
+	}
+
+	w.Header().Set(httpheader.ContentType.String(), fmt.Sprintf("%s; charset=utf-8", mimetype.Csv.String()))
+	w.Header().Set(httpheader.ContentDisposition.String(), fmt.Sprintf("attachment; filename=\"%s-rsvps.csv\"", e.ID))
+
+	// headers have already been sent once writing begins, so errors can only be logged
+	err = export.RSVPs(w, view.RSVPs)
+	if err != nil {
+		app.logger.Error(err.Error())
+	}
+}
+
+func (app *application) adminCancelRSVP(w http.ResponseWriter, r *http.Request) {
+	// get name and id
+	vars := mux.Vars(r)
+	name := vars["name"]
+	id := vars["id"]
+
+	// get event
+	e, err := app.eventService.ByName(name)
+	if errors.Is(err, events.ErrEventNotFound) || (err == nil && e.RSVP == nil) {
+		app.pageNotFound(w, r)
+		return
+	} else if err != nil {
+		app.serveError(w, r, err)
+		return
+	}
+
+	// remove it, which moves the waitlist up
+	seated, err := app.rsvpService.Cancel(name, id, e.RSVP.Capacity)
+	if errors.Is(err, rsvp.ErrRSVPNotFound) {
+		app.pageNotFound(w, r)
+		return
+	} else if err != nil {
+		app.serveError(w, r, err)
+		return
+	}
+
+	app.logger.Info(fmt.Sprintf("Cancelled RSVP `%s` to `%s`", id, name))
+	for _, s := range seated {
+		app.logger.Info(fmt.Sprintf("Seated RSVP `%s` to `%s` from the waitlist", s.ID, name))
+	}
+	http.Redirect(w, r, "/admin/events", http.StatusSeeOther)
+}
+
 func (app *application) pageSectionByYear(w http.ResponseWriter, r *http.Request) {
+	format := app.negotiatePage(w, r)
+
 	// get year
 	vars := mux.Vars(r)
 	yearAsString := vars["year"]
@@ -242,68 +1062,262 @@ func (app *application) pageSectionByYear(w http.ResponseWriter, r *http.Request
 	if yearAsString != "unknown" {
 		yearAsInt, err = strconv.Atoi(yearAsString)
 		if err != nil {
-			app.pageNotFound(w, r)
+			app.pageNotFoundAs(w, r, format, fmt.Errorf("%w: `%s`", purdoobahs.ErrSectionNotFound, yearAsString))
 			return
 		}
 	}
 
 	// get section by year
 	sectionByYear, err := app.purdoobahService.SectionByYear(yearAsInt)
+	if errors.Is(err, purdoobahs.ErrSectionNotFound) {
+		app.pageNotFoundAs(w, r, format, err)
+		return
+	} else if err != nil {
+		app.serveError(w, r, err)
+		return
+	}
+
+	// get social image
+	var socialImage string
+	if yearAsInt == -1 {
+		socialImage = app.cacheBuster.Get("/static/image/section/unknown.webp")
+	} else {
+		socialImage = app.cacheBuster.Get(fmt.Sprintf("/static/image/section/%v.webp", yearAsInt))
+	}
+	if !app.doesSectionHaveSocialImage(yearAsInt) {
+		socialImage = ""
+	}
+
+	if format != pageFormatHTML {
+		app.writePageData(w, r, format, sectionByYear, jsonld.NewSection(yearAsInt, sectionByYear, socialImage, baseURL))
+		return
+	}
+
+	var displayName string
+	if yearAsInt == -1 {
+		displayName = "Unknown Section"
+	} else {
+		displayName = fmt.Sprintf("%d Section", yearAsInt)
+	}
+
+	var url string
+	if yearAsInt == -1 {
+		url = "/section/unknown"
+	} else {
+		url = fmt.Sprintf("/section/%d", yearAsInt)
+	}
+
+	// get all years marched
+	allYearsMarched, err := app.purdoobahService.AllSectionYears()
+	if err != nil {
+		app.serveError(w, r, err)
+		return
+	}
+
+	// get its photos
+	photos, err := app.photoService.ByYear(yearAsInt)
+	if err != nil {
+		app.serveError(w, r, err)
+		return
+	}
+
+	// get who's who in its photo, if it's been tagged
+	var sectionPhoto *sectionPhotoView
+	taggedPhoto, err := app.sectionPhotoService.ByYear(yearAsInt)
+	if err == nil {
+		sectionPhoto = app.newSectionPhotoView(taggedPhoto)
+	} else if !errors.Is(err, sectionphotos.ErrSectionPhotoNotFound) {
+		app.serveError(w, r, err)
+		return
+	}
+
+	app.render(w, r, "section-by-year.gohtml", &templateData{
+		Page: page{
+			DisplayName: displayName,
+			URL:         url,
+		},
+		Purdoobahs:      sectionByYear,
+		Year:            yearAsInt,
+		AllYearsMarched: allYearsMarched,
+		Gallery:         app.newGalleryView(displayName, url, photos),
+		SectionPhoto:    sectionPhoto,
+		SectionImage:    socialImage,
+		Metadata: metadata{
+			SocialCard:  app.newSectionSocialCard(yearAsInt, sectionByYear),
+			Description: "OOOOOOOOOOOOOOOOOOLLLLDDDDDD",
+		},
+	})
+}
+
+func (app *application) pageToobahsassins(w http.ResponseWriter, r *http.Request) {
+	// get all games
+	games, err := app.gameService.All()
+	if err != nil {
+		app.serveError(w, r, err)
+		return
+	}
+
+	app.render(w, r, "toobahsassins.gohtml", &templateData{
+		Page: page{
+			DisplayName: "Toobahsassins",
+			URL:         "/toobahsassins",
+		},
+		Games: app.toobahsassinsGames(games, false),
+		Metadata: metadata{
+			Description: "Every game of Toobahsassins, and who was the last Purdoobah standing.",
+		},
+	})
+}
+
+func (app *application) pageToobahsassinsGame(w http.ResponseWriter, r *http.Request) {
+	// get id
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	// get game
+	game, err := app.gameService.ByID(id)
+	if errors.Is(err, toobahsassins.ErrGameNotFound) {
+		app.pageNotFound(w, r)
+		return
+	} else if err != nil {
+		app.serveError(w, r, err)
+		return
+	}
+
+	// keep the leaderboard live while the game is still being played
+	refresh := 0
+	if !game.IsOver() {
+		refresh = 60
+	}
+
+	app.render(w, r, "toobahsassins-game.gohtml", &templateData{
+		Page: page{
+			DisplayName: fmt.Sprintf("Toobahsassins %d", game.Season),
+			URL:         fmt.Sprintf("/toobahsassins/%s", game.ID),
+		},
+		Game: app.toobahsassinsGames([]*toobahsassins.Game{game}, false)[0],
+		Metadata: metadata{
+			Description: fmt.Sprintf("The leaderboard of the %d game of Toobahsassins.", game.Season),
+			Refresh:     refresh,
+		},
+	})
+}
+
+func (app *application) pageAdminToobahsassins(w http.ResponseWriter, r *http.Request) {
+	// get the season to create a game for, defaulting to the current one
+	season := purdoobahs.CurrentAcademicYear()
+	if seasonAsString := r.URL.Query().Get("season"); seasonAsString != "" {
+		var err error
+		season, err = strconv.Atoi(seasonAsString)
+		if err != nil {
+			app.clientError(w, r, http.StatusBadRequest)
+			return
+		}
+	}
+
+	// get the season's section, to pick the players from
+	roster, err := app.purdoobahService.SectionByYear(season)
+	if err != nil && !errors.Is(err, purdoobahs.ErrSectionNotFound) {
+		app.serveError(w, r, err)
+		return
+	}
+
+	// get all section years, for switching between them
+	allYearsMarched, err := app.purdoobahService.AllSectionYears()
+	if err != nil {
+		app.serveError(w, r, err)
+		return
+	}
+
+	// get all games
+	games, err := app.gameService.All()
+	if err != nil {
+		app.serveError(w, r, err)
+		return
+	}
+
+	app.render(w, r, "admin-toobahsassins.gohtml", &templateData{
+		Page: page{
+			DisplayName: "Toobahsassins Admin",
+			URL:         "/admin/toobahsassins",
+		},
+		Year:            season,
+		AllYearsMarched: allYearsMarched,
+		Purdoobahs:      roster,
+		Games:           app.toobahsassinsGames(games, true),
+		CSRFToken:       csrf.Token(r),
+	})
+}
+
+func (app *application) adminCreateToobahsassinsGame(w http.ResponseWriter, r *http.Request) {
+	err := r.ParseForm()
 	if err != nil {
-		app.serveError(w, err)
+		app.clientError(w, r, http.StatusBadRequest)
 		return
 	}
 
-	if len(sectionByYear) == 0 {
-		app.pageNotFound(w, r)
+	// get season
+	season, err := strconv.Atoi(r.PostForm.Get("season"))
+	if err != nil {
+		app.clientError(w, r, http.StatusBadRequest)
 		return
 	}
 
-	// get social image
-	var socialImage string
-	if yearAsInt == -1 {
-		socialImage = app.cacheBuster.Get("/static/image/section/unknown.webp")
-	} else {
-		socialImage = app.cacheBuster.Get(fmt.Sprintf("/static/image/section/%v.webp", yearAsInt))
-	}
-	if !app.doesSectionHaveSocialImage(yearAsInt) {
-		socialImage = ""
+	// every player has to be a Purdoobah
+	players := r.PostForm["player"]
+	for _, id := range players {
+		_, err := app.purdoobahService.ByName(id)
+		if err != nil {
+			app.clientError(w, r, http.StatusBadRequest)
+			return
+		}
 	}
 
-	var displayName string
-	if yearAsInt == -1 {
-		displayName = "Unknown Section"
-	} else {
-		displayName = fmt.Sprintf("%d Section", yearAsInt)
+	// create the game
+	game, err := app.gameService.Create(season, players)
+	if errors.Is(err, toobahsassins.ErrNotEnoughPlayers) {
+		app.clientError(w, r, http.StatusBadRequest)
+		return
+	} else if err != nil {
+		app.serveError(w, r, err)
+		return
 	}
 
-	var url string
-	if yearAsInt == -1 {
-		url = "/section/unknown"
-	} else {
-		url = fmt.Sprintf("/section/%d", yearAsInt)
-	}
+	app.logger.Info(fmt.Sprintf("Created Toobahsassins game `%s` for %d with %d players", game.ID, game.Season, len(game.Players)))
+	http.Redirect(w, r, "/admin/toobahsassins", http.StatusSeeOther)
+}
 
-	// get all years marched
-	allYearsMarched, err := app.purdoobahService.AllSectionYears()
-	if err != nil {
-		app.serveError(w, err)
+func (app *application) adminEliminateToobahsassinsTarget(w http.ResponseWriter, r *http.Request) {
+	// get id
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	// record the elimination
+	game, err := app.gameService.Eliminate(id, r.PostFormValue("assassin"))
+	switch {
+	case errors.Is(err, toobahsassins.ErrGameNotFound):
+		app.pageNotFound(w, r)
+		return
+	case errors.Is(err, toobahsassins.ErrPlayerNotFound):
+		app.clientError(w, r, http.StatusBadRequest)
+		return
+	case errors.Is(err, toobahsassins.ErrPlayerEliminated), errors.Is(err, toobahsassins.ErrGameOver):
+		app.clientError(w, r, http.StatusConflict)
+		return
+	case err != nil:
+		app.serveError(w, r, err)
 		return
 	}
 
-	app.render(w, r, "section-by-year.gohtml", &templateData{
-		Page: page{
-			DisplayName: displayName,
-			URL:         url,
-		},
-		Purdoobahs:      sectionByYear,
-		Year:            yearAsInt,
-		AllYearsMarched: allYearsMarched,
-		Metadata: metadata{
-			SocialImage: socialImage,
-			Description: "OOOOOOOOOOOOOOOOOOLLLLDDDDDD",
-		},
-	})
+	// the victory is derived from the saved game, but is lost if the game is ever deleted
+	if game.IsOver() {
+		app.logger.Info(fmt.Sprintf(
+			"`%s` won the %d Spoonsassins; add it to their asset file to keep it if the game is ever deleted",
+			game.Victor, game.Season,
+		))
+	}
+
+	http.Redirect(w, r, "/admin/toobahsassins", http.StatusSeeOther)
 }
 
 func (app *application) pageNotFound(w http.ResponseWriter, r *http.Request) {
@@ -359,6 +1373,98 @@ func (app *application) fileHumansTxt(w http.ResponseWriter, r *http.Request) {
 	http.ServeFile(w, r, fmt.Sprintf(".%s", app.cacheBuster.Get("/static/file/humans.txt")))
 }
 
+func (app *application) fileCalendarIcs(w http.ResponseWriter, r *http.Request) {
+	// get all events
+	allEvents, err := app.eventService.All()
+	if err != nil {
+		app.serveError(w, r, err)
+		return
+	}
+
+	// encode it before sending anything, so that errors can still be served
+	var buf bytes.Buffer
+	err = app.newCalendar(allEvents, time.Now()).Encode(&buf)
+	if err != nil {
+		app.serveError(w, r, err)
+		return
+	}
+
+	w.Header().Set(httpheader.ContentType.String(), fmt.Sprintf("%s; charset=utf-8", mimetype.Calendar.String()))
+	_, err = w.Write(buf.Bytes())
+	if err != nil {
+		app.logger.Error(err.Error())
+	}
+}
+
+func (app *application) fileImage(w http.ResponseWriter, r *http.Request) {
+	// only cache-busted images can be resized, as variants are cached by the hash of the image they're made from
+	src := r.URL.Query().Get("src")
+	hash, ok := app.cacheBuster.Hash(src)
+	if !ok || !strings.HasPrefix(src, "/static/image/") {
+		app.clientError(w, r, http.StatusNotFound)
+		return
+	}
+
+	width, err := strconv.Atoi(r.URL.Query().Get("w"))
+	if err != nil || !app.resizer.IsAllowedWidth(width) {
+		app.clientError(w, r, http.StatusBadRequest)
+		return
+	}
+
+	format := imageresize.DefaultFormat(src)
+	if fm := r.URL.Query().Get("fm"); fm != "" {
+		format = imageresize.Format(fm)
+	}
+
+	variantPath, err := app.resizer.Variant(strings.TrimPrefix(src, "/"), hash, width, format)
+	if errors.Is(err, imageresize.ErrUnsupportedWidth) || errors.Is(err, imageresize.ErrUnsupportedFormat) {
+		app.clientError(w, r, http.StatusBadRequest)
+		return
+	} else if err != nil {
+		app.serveError(w, r, err)
+		return
+	}
+
+	switch format {
+	case imageresize.PNG:
+		w.Header().Set(httpheader.ContentType.String(), mimetype.Png.String())
+	case imageresize.WebP:
+		w.Header().Set(httpheader.ContentType.String(), mimetype.Webp.String())
+	default:
+		w.Header().Set(httpheader.ContentType.String(), mimetype.Jpeg.String())
+	}
+	http.ServeFile(w, r, variantPath)
+}
+
+func (app *application) fileSocialCard(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	// old versions of a card aren't kept, so only the current one is served
+	card, err := app.socialCardOf(vars["kind"], vars["id"])
+	if errors.Is(err, purdoobahs.ErrPurdoobahNotFound) ||
+		errors.Is(err, purdoobahs.ErrSectionNotFound) ||
+		errors.Is(err, traditions.ErrTraditionNotFound) {
+		app.clientError(w, r, http.StatusNotFound)
+		return
+	} else if err != nil {
+		app.serveError(w, r, err)
+		return
+	}
+	if card.Card.Hash() != vars["hash"] {
+		app.clientError(w, r, http.StatusNotFound)
+		return
+	}
+
+	cardPath, err := app.socialCards.Render(card.Card)
+	if err != nil {
+		app.serveError(w, r, err)
+		return
+	}
+
+	w.Header().Set(httpheader.ContentType.String(), mimetype.Jpeg.String())
+	http.ServeFile(w, r, cardPath)
+}
+
 func (app *application) apiHealthCheck(w http.ResponseWriter, r *http.Request) {
 	w.Header().Add(
 		httpheader.ContentType.String(),
@@ -366,7 +1472,7 @@ func (app *application) apiHealthCheck(w http.ResponseWriter, r *http.Request) {
 	)
 	_, err := w.Write([]byte("{ \"status\": \"success\"}"))
 	if err != nil {
-		app.serveError(w, err)
+		app.serveError(w, r, err)
 		return
 	}
 }
@@ -383,7 +1489,7 @@ func (app *application) apiAnalytics(w http.ResponseWriter, r *http.Request) {
 	// body
 	screenWidth, err := strconv.Atoi(r.FormValue("screen_width"))
 	if err != nil {
-		app.serveError(w, err)
+		app.apiError(w, r, apierror.BadRequest("screen_width must be an integer").WithDetail("parameter", "screen_width"))
 		return
 	}
 	body := plausibleanalytics.NewPlausibleAnalyticsBody(
@@ -395,7 +1501,7 @@ func (app *application) apiAnalytics(w http.ResponseWriter, r *http.Request) {
 	)
 	bodyBytes, err := json.Marshal(body)
 	if err != nil {
-		app.serveError(w, err)
+		app.serveError(w, r, err)
 		return
 	}
 
@@ -407,14 +1513,14 @@ func (app *application) apiAnalytics(w http.ResponseWriter, r *http.Request) {
 		bytes.NewBuffer(bodyBytes),
 	)
 	if err != nil {
-		app.serveError(w, err)
+		app.serveError(w, r, err)
 		return
 	}
 
 	// headers
 	req.Header.Set(httpheader.ContentType.String(), mimetype.Json.String())
 	req.Header.Set(httpheader.UserAgent.String(), r.FormValue("user_agent"))
-	req.Header.Set(httpheader.NonstandardXForwardedFor.String(), r.RemoteAddr)
+	req.Header.Set(httpheader.NonstandardXForwardedFor.String(), trustedproxy.ClientIP(r))
 
 	// print headers
 	if reqHeadersBytes, err := json.Marshal(req.Header); err == nil {
@@ -427,7 +1533,7 @@ func (app *application) apiAnalytics(w http.ResponseWriter, r *http.Request) {
 	// POST analytics event
 	resp, err := app.httpClient.Do(req)
 	if err != nil {
-		app.serveError(w, err)
+		app.serveError(w, r, err)
 		return
 	}
 	defer func() {
@@ -448,7 +1554,7 @@ func (app *application) apiAnalytics(w http.ResponseWriter, r *http.Request) {
 	)
 	_, err = w.Write([]byte("{ \"status\": \"success\"}"))
 	if err != nil {
-		app.serveError(w, err)
+		app.serveError(w, r, err)
 		return
 	}
 }
@@ -457,27 +1563,12 @@ func (app *application) apiAllPurdoobahs(w http.ResponseWriter, r *http.Request)
 	// get all purdoobahs
 	allPurdoobahs, err := app.purdoobahService.All()
 	if err != nil {
-		app.serveError(w, err)
-		return
-	}
-
-	// convert to JSON bytes
-	b, err := json.Marshal(allPurdoobahs)
-	if err != nil {
-		app.serveError(w, err)
+		app.serveError(w, r, err)
 		return
 	}
 
 	// send it out
-	w.Header().Add(
-		httpheader.ContentType.String(),
-		fmt.Sprintf("%s; charset=utf-8", mimetype.Json.String()),
-	)
-	_, err = w.Write(b)
-	if err != nil {
-		app.serveError(w, err)
-		return
-	}
+	app.writePurdoobahList(w, r, allPurdoobahs, "purdoobahs")
 }
 
 func (app *application) apiPurdoobahByName(w http.ResponseWriter, r *http.Request) {
@@ -485,17 +1576,23 @@ func (app *application) apiPurdoobahByName(w http.ResponseWriter, r *http.Reques
 	vars := mux.Vars(r)
 	name := vars["name"]
 
+	// only JSON is offered, but asking for another format shouldn't silently get JSON
+	_, ok := app.negotiateAPI(w, r, purdoobahOffers)
+	if !ok {
+		return
+	}
+
 	// get purdoobah
 	purdoobahByName, err := app.purdoobahService.ByName(name)
 	if err != nil {
-		app.apiNotFound(w, r)
+		app.apiServiceError(w, r, err)
 		return
 	}
 
 	// convert to JSON bytes
 	b, err := json.Marshal(purdoobahByName)
 	if err != nil {
-		app.serveError(w, err)
+		app.serveError(w, r, err)
 		return
 	}
 
@@ -506,7 +1603,7 @@ func (app *application) apiPurdoobahByName(w http.ResponseWriter, r *http.Reques
 	)
 	_, err = w.Write(b)
 	if err != nil {
-		app.serveError(w, err)
+		app.serveError(w, r, err)
 		return
 	}
 }
@@ -515,14 +1612,22 @@ func (app *application) apiCurrentSection(w http.ResponseWriter, r *http.Request
 	// get current section
 	currentSection, err := app.purdoobahService.CurrentSection()
 	if err != nil {
-		app.apiNotFound(w, r)
+		app.apiServiceError(w, r, err)
 		return
 	}
+	currentSection = &purdoobahs.Section{
+		StudentLeaders: withoutPlaceholders(currentSection.StudentLeaders),
+		SuperSeniors:   withoutPlaceholders(currentSection.SuperSeniors),
+		Seniors:        withoutPlaceholders(currentSection.Seniors),
+		Juniors:        withoutPlaceholders(currentSection.Juniors),
+		Sophomores:     withoutPlaceholders(currentSection.Sophomores),
+		Freshmen:       withoutPlaceholders(currentSection.Freshmen),
+	}
 
 	// convert to JSON bytes
 	b, err := json.Marshal(currentSection)
 	if err != nil {
-		app.serveError(w, err)
+		app.serveError(w, r, err)
 		return
 	}
 
@@ -533,7 +1638,7 @@ func (app *application) apiCurrentSection(w http.ResponseWriter, r *http.Request
 	)
 	_, err = w.Write(b)
 	if err != nil {
-		app.serveError(w, err)
+		app.serveError(w, r, err)
 		return
 	}
 }
@@ -546,21 +1651,40 @@ func (app *application) apiSectionByYear(w http.ResponseWriter, r *http.Request)
 	// convert from string to int
 	yearAsInt, err := strconv.Atoi(yearAsString)
 	if err != nil {
-		app.apiNotFound(w, r)
+		app.apiError(w, r, apierror.BadRequest("year must be an integer").WithDetail("parameter", "year"))
 		return
 	}
 
 	// get section by year
 	sectionByYear, err := app.purdoobahService.SectionByYear(yearAsInt)
 	if err != nil {
-		app.serveError(w, err)
+		app.apiServiceError(w, r, err)
+		return
+	}
+
+	// send it out
+	filename := fmt.Sprintf("section-%d", yearAsInt)
+	if yearAsInt == -1 {
+		filename = "section-unknown"
+	}
+	app.writePurdoobahList(w, r, sectionByYear, filename)
+}
+
+func (app *application) apiAllTraditions(w http.ResponseWriter, r *http.Request) {
+	// get all traditions
+	allTraditions, err := app.traditionService.All()
+	if err != nil {
+		app.serveError(w, r, err)
 		return
 	}
+	for i, t := range allTraditions {
+		allTraditions[i] = t.WithoutPlaceholder()
+	}
 
 	// convert to JSON bytes
-	b, err := json.Marshal(sectionByYear)
+	b, err := json.Marshal(allTraditions)
 	if err != nil {
-		app.serveError(w, err)
+		app.serveError(w, r, err)
 		return
 	}
 
@@ -571,52 +1695,276 @@ func (app *application) apiSectionByYear(w http.ResponseWriter, r *http.Request)
 	)
 	_, err = w.Write(b)
 	if err != nil {
-		app.serveError(w, err)
+		app.serveError(w, r, err)
 		return
 	}
 }
 
-func (app *application) apiAllTraditions(w http.ResponseWriter, r *http.Request) {
-	// get all traditions
-	allTraditions, err := app.traditionService.All()
+func (app *application) apiTraditionByName(w http.ResponseWriter, r *http.Request) {
+	// get name
+	vars := mux.Vars(r)
+	name := vars["name"]
+
+	// get tradition
+	traditionByName, err := app.traditionService.ByName(name)
+	if err != nil {
+		app.apiServiceError(w, r, err)
+		return
+	}
+
+	// send it out
+	app.writeJSON(w, r, http.StatusOK, traditionByName)
+}
+
+func (app *application) apiStats(w http.ResponseWriter, r *http.Request) {
+	// compute statistics
+	sectionStats, err := stats.Compute(app.purdoobahService)
+	if err != nil {
+		app.serveError(w, r, err)
+		return
+	}
+
+	// send it out
+	app.writeJSON(w, r, http.StatusOK, sectionStats)
+}
+
+func (app *application) apiCohorts(w http.ResponseWriter, r *http.Request) {
+	// compute cohorts
+	cohorts, err := stats.ComputeCohorts(app.purdoobahService)
+	if err != nil {
+		app.serveError(w, r, err)
+		return
+	}
+
+	// send it out
+	app.writeJSON(w, r, http.StatusOK, cohorts)
+}
+
+func (app *application) apiAlumni(w http.ResponseWriter, r *http.Request) {
+	// get the directory's filter
+	filter, err := alumniFilter(r)
+	if err != nil {
+		app.apiError(w, r, apierror.BadRequest(err.Error()).WithDetail("parameter", "graduation_year"))
+		return
+	}
+
+	// get the alumni directory
+	directory, err := alumni.NewDirectory(app.purdoobahService, filter)
+	if err != nil {
+		app.serveError(w, r, err)
+		return
+	}
+	for _, entry := range directory.Alumni {
+		entry.Purdoobah = entry.Purdoobah.WithoutPlaceholder()
+	}
+
+	// send it out
+	app.writeJSON(w, r, http.StatusOK, directory)
+}
+
+func (app *application) apiLeaders(w http.ResponseWriter, r *http.Request) {
+	// get the section's leaders, year by year
+	history, err := leaders.NewHistory(app.purdoobahService)
+	if err != nil {
+		app.serveError(w, r, err)
+		return
+	}
+	for _, year := range history {
+		for _, leader := range year.Leaders {
+			leader.Purdoobah = leader.Purdoobah.WithoutPlaceholder()
+		}
+	}
+
+	// send it out
+	app.writeJSON(w, r, http.StatusOK, history)
+}
+
+func (app *application) apiMap(w http.ResponseWriter, r *http.Request) {
+	// get the Purdoobahs of the requested section
+	members, _, err := app.mapPurdoobahs(r.URL.Query().Get("year"))
 	if err != nil {
-		app.serveError(w, err)
+		app.apiServiceError(w, r, err)
 		return
 	}
 
 	// convert to JSON bytes
-	b, err := json.Marshal(allTraditions)
+	b, err := json.Marshal(hometownFeatures(app.groupHometowns(members)))
 	if err != nil {
-		app.serveError(w, err)
+		app.serveError(w, r, err)
 		return
 	}
 
 	// send it out
-	w.Header().Add(
+	w.Header().Set(
 		httpheader.ContentType.String(),
-		fmt.Sprintf("%s; charset=utf-8", mimetype.Json.String()),
+		fmt.Sprintf("%s; charset=utf-8", mimetype.GeoJson.String()),
 	)
 	_, err = w.Write(b)
 	if err != nil {
-		app.serveError(w, err)
+		app.logger.Error(err.Error())
+	}
+}
+
+func (app *application) apiAllCravers(w http.ResponseWriter, r *http.Request) {
+	// get all cravers
+	allCravers, err := app.craverService.All()
+	if err != nil {
+		app.serveError(w, r, err)
+		return
+	}
+	for i, c := range allCravers {
+		allCravers[i] = c.WithoutPlaceholder()
+	}
+
+	// send it out
+	app.writeJSON(w, r, http.StatusOK, allCravers)
+}
+
+func (app *application) apiCraverByName(w http.ResponseWriter, r *http.Request) {
+	// get name
+	vars := mux.Vars(r)
+	name := vars["name"]
+
+	// get craver
+	craverByName, err := app.craverService.ByName(name)
+	if err != nil {
+		app.apiServiceError(w, r, err)
+		return
+	}
+
+	// send it out
+	app.writeJSON(w, r, http.StatusOK, craverByName)
+}
+
+func (app *application) apiAllAchievements(w http.ResponseWriter, r *http.Request) {
+	// get all achievements
+	allAchievements, err := app.achievementService.All()
+	if err != nil {
+		app.serveError(w, r, err)
+		return
+	}
+
+	// send it out
+	app.writeJSON(w, r, http.StatusOK, allAchievements)
+}
+
+func (app *application) apiAchievementByID(w http.ResponseWriter, r *http.Request) {
+	// get ID
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	// get achievement
+	achievementByID, err := app.achievementService.ByID(id)
+	if err != nil {
+		app.apiServiceError(w, r, err)
+		return
+	}
+
+	// get everyone holding it
+	allPurdoobahs, err := app.purdoobahService.All()
+	if err != nil {
+		app.serveError(w, r, err)
+		return
+	}
+
+	// send it out
+	app.writeJSON(w, r, http.StatusOK, struct {
+		*achievements.Achievement
+		Holders []*achievements.Holder `json:"holders"`
+	}{
+		Achievement: achievementByID,
+		Holders:     achievementByID.Holders(allPurdoobahs),
+	})
+}
+
+func (app *application) apiAllEvents(w http.ResponseWriter, r *http.Request) {
+	// get all events
+	allEvents, err := app.eventService.All()
+	if err != nil {
+		app.serveError(w, r, err)
 		return
 	}
+
+	// send it out
+	app.writeJSON(w, r, http.StatusOK, allEvents)
+}
+
+func (app *application) apiEventByName(w http.ResponseWriter, r *http.Request) {
+	// get name
+	vars := mux.Vars(r)
+	name := vars["name"]
+
+	// get event
+	eventByName, err := app.eventService.ByName(name)
+	if err != nil {
+		app.apiServiceError(w, r, err)
+		return
+	}
+
+	// send it out
+	app.writeJSON(w, r, http.StatusOK, eventByName)
 }
 
-func (app *application) apiNotFound(w http.ResponseWriter, r *http.Request) {
-	app.clientError(w, http.StatusNotFound)
+func (app *application) apiAllToobahsassinsGames(w http.ResponseWriter, r *http.Request) {
+	// get all games
+	games, err := app.gameService.All()
+	if err != nil {
+		app.serveError(w, r, err)
+		return
+	}
+
+	// nobody's target is revealed
+	publicGames := make([]*toobahsassins.Game, 0, len(games))
+	for _, game := range games {
+		publicGames = append(publicGames, game.Public())
+	}
+
+	// send it out
+	app.writeJSON(w, r, http.StatusOK, publicGames)
 }
 
-func (app *application) serveError(w http.ResponseWriter, err error) {
-	trace := fmt.Sprintf("%s\n%s", err.Error(), debug.Stack())
-	err = app.logger.(*logger.Logger).ErrorLog.Output(2, trace)
+func (app *application) apiToobahsassinsGameByID(w http.ResponseWriter, r *http.Request) {
+	// get id
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	// get game
+	game, err := app.gameService.ByID(id)
 	if err != nil {
-		app.serveError(w, err)
+		app.apiServiceError(w, r, err)
 		return
 	}
-	http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+
+	// send it out, without revealing anybody's target
+	app.writeJSON(w, r, http.StatusOK, game.Public())
 }
 
-func (app *application) clientError(w http.ResponseWriter, status int) {
-	http.Error(w, http.StatusText(status), status)
+func (app *application) apiGraphQL(w http.ResponseWriter, r *http.Request) {
+	// GET requests carry the query in the URL, POST requests carry it in a JSON body
+	req := &graphqlapi.Request{}
+	if r.Method == http.MethodPost {
+		r.Body = http.MaxBytesReader(w, r.Body, 1<<16)
+		err := json.NewDecoder(r.Body).Decode(req)
+		if err != nil {
+			app.apiError(w, r, apierror.BadRequest("request body must be a JSON GraphQL request"))
+			return
+		}
+	} else {
+		req.Query = r.URL.Query().Get("query")
+		req.OperationName = r.URL.Query().Get("operationName")
+		if variables := r.URL.Query().Get("variables"); variables != "" {
+			err := json.Unmarshal([]byte(variables), &req.Variables)
+			if err != nil {
+				app.apiError(w, r, apierror.BadRequest("variables must be a JSON object").WithDetail("parameter", "variables"))
+				return
+			}
+		}
+	}
+	if strings.TrimSpace(req.Query) == "" {
+		app.apiError(w, r, apierror.BadRequest("query must not be empty").WithDetail("parameter", "query"))
+		return
+	}
+
+	// GraphQL errors are reported inside of the result, so the status is always OK
+	app.writeJSON(w, r, http.StatusOK, app.graphQL.Execute(r.Context(), req))
 }
@@ -5,6 +5,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/purdoobahs/purdoobahs.com/internal/gallery"
 	"github.com/purdoobahs/purdoobahs.com/internal/purdoobahs"
 	"github.com/purdoobahs/purdoobahs.com/internal/sitemap"
 	"github.com/purdoobahs/purdoobahs.com/internal/traditions"
@@ -105,7 +106,7 @@ func (app *application) generateRootSitemap() error {
 	rootSitemap := sitemap.NewFile([]sitemap.UrlEntry{})
 
 	// add new UrlEntry for each root route
-	routes := []string{"", "alumni", "tradition", "cravers-hall-of-fame"}
+	routes := []string{"", "alumni", "tradition", "cravers-hall-of-fame", "cravers-hall-of-fame/apply", "stats", "stats/cohorts", "map", "leaders", "events", "gallery", "toobahsassins"}
 	for _, route := range routes {
 		var images []sitemap.ImageEntry
 		urlEntry, err := sitemap.NewUrlEntry(
@@ -178,6 +179,24 @@ func (app *application) generateProfilesSitemap(allPurdoobahs []*purdoobahs.Purd
 			return err
 		}
 		profilesSitemap.AddUrl(urlEntry)
+
+		// generate gallery URL entry, if they're in any photos
+		photos, err := app.photoService.ByPurdoobah(purdoobah.ID)
+		if err != nil {
+			return err
+		}
+		if len(photos) > 0 {
+			galleryEntry, err := app.newGalleryUrlEntry(
+				fmt.Sprintf("%s/%s/gallery", homeUrl, purdoobah.ID),
+				lastModified,
+				photos,
+				imageEntryGeoLocation,
+			)
+			if err != nil {
+				return err
+			}
+			profilesSitemap.AddUrl(galleryEntry)
+		}
 	}
 
 	// write profiles sitemap to disk
@@ -264,6 +283,24 @@ func (app *application) generateSectionsSitemap(allSectionYears []int, imageEntr
 			return err
 		}
 		sectionsSitemap.AddUrl(urlEntry)
+
+		// generate gallery URL entry, if it has any photos
+		photos, err := app.photoService.ByYear(uniqueYear)
+		if err != nil {
+			return err
+		}
+		if len(photos) > 0 {
+			galleryEntry, err := app.newGalleryUrlEntry(
+				fmt.Sprintf("%s/%d/gallery", homeUrl, uniqueYear),
+				lastModified,
+				photos,
+				imageEntryGeoLocation,
+			)
+			if err != nil {
+				return err
+			}
+			sectionsSitemap.AddUrl(galleryEntry)
+		}
 	}
 
 	// write sections sitemap to disk
@@ -323,6 +360,24 @@ func (app *application) generateTraditionsSitemap(allTraditions []*traditions.Tr
 			return err
 		}
 		traditionsSitemap.AddUrl(urlEntry)
+
+		// generate gallery URL entry, if it has any photos
+		photos, err := app.photoService.ByTradition(tradition.ID)
+		if err != nil {
+			return err
+		}
+		if len(photos) > 0 {
+			galleryEntry, err := app.newGalleryUrlEntry(
+				fmt.Sprintf("%s/%s/gallery", homeUrl, tradition.ID),
+				lastModified,
+				photos,
+				imageEntryGeoLocation,
+			)
+			if err != nil {
+				return err
+			}
+			traditionsSitemap.AddUrl(galleryEntry)
+		}
 	}
 
 	// write traditions sitemap to disk
@@ -340,3 +395,26 @@ func (app *application) generateTraditionsSitemap(allTraditions []*traditions.Tr
 
 	return nil
 }
+
+// newGalleryUrlEntry generates the URL entry of a gallery page, with an image entry for every one of its photos (each
+// under its own license).
+func (app *application) newGalleryUrlEntry(url, lastModified string, photos []*gallery.Photo, imageEntryGeoLocation string) (sitemap.UrlEntry, error) {
+	baseImageUrl := "https://www.purdoobahs.com"
+
+	var images []sitemap.ImageEntry
+	for _, photo := range photos {
+		image, err := sitemap.NewImageEntry(
+			fmt.Sprintf("%s%s", baseImageUrl, photo.Metadata.Image.File),
+			photo.Caption,
+			fmt.Sprintf("%s (Photo by %s)", photo.Metadata.Image.Alt, photo.Photographer),
+			imageEntryGeoLocation,
+			photo.License.URL,
+		)
+		if err != nil {
+			return sitemap.UrlEntry{}, err
+		}
+		images = append(images, image)
+	}
+
+	return sitemap.NewUrlEntry(url, lastModified, sitemap.Weekly, 0.5, images)
+}
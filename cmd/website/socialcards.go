@@ -0,0 +1,170 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/purdoobahs/purdoobahs.com/internal/purdoobahs"
+	"github.com/purdoobahs/purdoobahs.com/internal/socialcard"
+	"github.com/purdoobahs/purdoobahs.com/internal/traditions"
+)
+
+// socialCardFallbackPhoto stands in for sections that don't have a photo.
+const socialCardFallbackPhoto = "/static/image/logo/purdue-pete-wearing-sousa.webp"
+
+// pageSocialCard is the generated social card of a page about a single Purdoobah, section, or tradition.
+type pageSocialCard struct {
+	// Kind and ID are what the card is of, as they appear in its URL (e.g. "purdoobah" and "wizard").
+	Kind string
+	ID   string
+
+	Card *socialcard.Card
+}
+
+// URL is where the card is served from. It changes whenever the card does, so that it can be cached forever.
+func (sc *pageSocialCard) URL() string {
+	return fmt.Sprintf("/social-card/%s/%s.%s.jpg", sc.Kind, sc.ID, sc.Card.Hash())
+}
+
+// Width and Height are the size of the card, in pixels.
+func (sc *pageSocialCard) Width() int {
+	return socialcard.Width
+}
+
+func (sc *pageSocialCard) Height() int {
+	return socialcard.Height
+}
+
+func (app *application) newPurdoobahSocialCard(p *purdoobahs.Purdoobah) *pageSocialCard {
+	years := make([]int, 0, len(p.Marching.YearsMarched))
+	for _, year := range p.Marching.YearsMarched {
+		if year != -1 {
+			years = append(years, year)
+		}
+	}
+
+	subtitle := "Marching history unknown"
+	if len(years) > 0 {
+		subtitle = fmt.Sprintf("Marched %s", prettyIntSlice(years))
+	}
+
+	return &pageSocialCard{
+		Kind: "purdoobah",
+		ID:   p.ID,
+		Card: &socialcard.Card{
+			Photo:    strings.TrimPrefix(p.Metadata.Image.File, "/"),
+			Title:    p.Name,
+			Subtitle: subtitle,
+		},
+	}
+}
+
+func (app *application) newTraditionSocialCard(t *traditions.Tradition) *pageSocialCard {
+	return &pageSocialCard{
+		Kind: "tradition",
+		ID:   t.ID,
+		Card: &socialcard.Card{
+			Photo:    strings.TrimPrefix(t.Metadata.Image.File, "/"),
+			Title:    t.Name,
+			Subtitle: t.Description,
+		},
+	}
+}
+
+func (app *application) newSectionSocialCard(year int, members []*purdoobahs.Purdoobah) *pageSocialCard {
+	id := "unknown"
+	title := "Unknown Section"
+	subtitle := fmt.Sprintf("%d Purdoobahs whose marching history is unknown", len(members))
+	if year != -1 {
+		id = fmt.Sprint(year)
+		title = fmt.Sprintf("%d Section", year)
+		subtitle = fmt.Sprintf("%d Purdoobahs marched in %d", len(members), year)
+	}
+
+	photo := app.cacheBuster.Get(socialCardFallbackPhoto)
+	if app.cacheBuster.Get(fmt.Sprintf("/static/image/section/%d.webp", year)) != "" {
+		photo = app.cacheBuster.Get(fmt.Sprintf("/static/image/section/%d.webp", year))
+	}
+
+	return &pageSocialCard{
+		Kind: "section",
+		ID:   id,
+		Card: &socialcard.Card{
+			Photo:    strings.TrimPrefix(photo, "/"),
+			Title:    title,
+			Subtitle: subtitle,
+		},
+	}
+}
+
+// socialCardOf rebuilds the social card of the given kind and ID, so that cards can be drawn when they're first asked
+// for instead of all at once.
+func (app *application) socialCardOf(kind, id string) (*pageSocialCard, error) {
+	switch kind {
+	case "purdoobah":
+		p, err := app.purdoobahService.ByName(id)
+		if err != nil {
+			return nil, err
+		}
+		return app.newPurdoobahSocialCard(p), nil
+	case "tradition":
+		t, err := app.traditionService.ByName(id)
+		if err != nil {
+			return nil, err
+		}
+		return app.newTraditionSocialCard(t), nil
+	case "section":
+		year := -1
+		if id != "unknown" {
+			var err error
+			year, err = strconv.Atoi(id)
+			if err != nil {
+				return nil, fmt.Errorf("%w: `%s`", purdoobahs.ErrSectionNotFound, id)
+			}
+		}
+		members, err := app.purdoobahService.SectionByYear(year)
+		if err != nil {
+			return nil, err
+		}
+		return app.newSectionSocialCard(year, members), nil
+	default:
+		return nil, fmt.Errorf("unknown kind of social card: `%s`", kind)
+	}
+}
+
+// currentSocialCards returns the hashes of every Purdoobah's, section's, and tradition's social card, as they'd be drawn
+// today.
+func (app *application) currentSocialCards() (map[string]bool, error) {
+	hashes := make(map[string]bool)
+
+	allPurdoobahs, err := app.purdoobahService.All()
+	if err != nil {
+		return hashes, err
+	}
+	for _, p := range allPurdoobahs {
+		hashes[app.newPurdoobahSocialCard(p).Card.Hash()] = true
+	}
+
+	allTraditions, err := app.traditionService.All()
+	if err != nil {
+		return hashes, err
+	}
+	for _, t := range allTraditions {
+		hashes[app.newTraditionSocialCard(t).Card.Hash()] = true
+	}
+
+	allSectionYears, err := app.purdoobahService.AllSectionYears()
+	if err != nil {
+		return hashes, err
+	}
+	for _, year := range allSectionYears {
+		members, err := app.purdoobahService.SectionByYear(year)
+		if err != nil {
+			return hashes, err
+		}
+		hashes[app.newSectionSocialCard(year, members).Card.Hash()] = true
+	}
+
+	return hashes, nil
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"fmt"
+	"html/template"
+	"math"
+	"strconv"
+
+	"github.com/purdoobahs/purdoobahs.com/internal/stats"
+	"github.com/purdoobahs/purdoobahs.com/internal/svgchart"
+)
+
+// maxChartCategories is the most categories (e.g. majors) a bar chart shows before the rest are left out.
+const maxChartCategories = 20
+
+// statsCharts renders the charts of the statistics page.
+func statsCharts(s *stats.Stats) map[string]template.HTML {
+	years := make([]string, 0, len(s.Years))
+	freshmen := svgchart.Series{Name: "Freshmen"}
+	sophomores := svgchart.Series{Name: "Sophomores"}
+	juniors := svgchart.Series{Name: "Juniors"}
+	seniors := svgchart.Series{Name: "Seniors"}
+	superSeniors := svgchart.Series{Name: "Super Seniors"}
+	studentLeaders := svgchart.Series{Name: "Student Leaders"}
+	for _, ys := range s.Years {
+		years = append(years, strconv.Itoa(ys.Year))
+		freshmen.Values = append(freshmen.Values, float64(ys.ClassStanding.Freshmen))
+		sophomores.Values = append(sophomores.Values, float64(ys.ClassStanding.Sophomores))
+		juniors.Values = append(juniors.Values, float64(ys.ClassStanding.Juniors))
+		seniors.Values = append(seniors.Values, float64(ys.ClassStanding.Seniors))
+		superSeniors.Values = append(superSeniors.Values, float64(ys.ClassStanding.SuperSeniors))
+		studentLeaders.Values = append(studentLeaders.Values, float64(ys.StudentLeaders))
+	}
+
+	return map[string]template.HTML{
+		"sectionSize": (&svgchart.ColumnChart{
+			Title:       "Section Size",
+			Description: "The number of Purdoobahs that marched each year, by class standing",
+			Labels:      years,
+			Series:      []svgchart.Series{freshmen, sophomores, juniors, seniors, superSeniors},
+			Width:       900,
+			Height:      360,
+		}).SVG(),
+		"studentLeaders": (&svgchart.ColumnChart{
+			Title:       "Student Leaders",
+			Description: "The number of student leaders each year",
+			Labels:      years,
+			Series:      []svgchart.Series{studentLeaders},
+			Colors:      []string{svgchart.Palette[5]},
+			Width:       900,
+			Height:      240,
+		}).SVG(),
+		"homeStates": (&svgchart.BarChart{
+			Title:       "Home States",
+			Description: "The number of Purdoobahs from each state",
+			Bars:        distributionBars(s.HomeStates),
+			Width:       900,
+			LabelWidth:  160,
+		}).SVG(),
+		"majors": (&svgchart.BarChart{
+			Title:       "Majors",
+			Description: fmt.Sprintf("The %d most common majors", maxChartCategories),
+			Bars:        distributionBars(s.Majors),
+			Width:       900,
+			LabelWidth:  320,
+		}).SVG(),
+	}
+}
+
+// distributionBars turns the most common values of a distribution into bars.
+func distributionBars(d *stats.Distribution) []svgchart.Bar {
+	bars := make([]svgchart.Bar, 0, maxChartCategories)
+	for i, count := range d.Counts {
+		if i == maxChartCategories {
+			break
+		}
+		bars = append(bars, svgchart.Bar{Label: count.Name, Value: float64(count.Count)})
+	}
+	return bars
+}
+
+// maxCohortLines is how many of the most recent cohorts are plotted alongside the average.
+const maxCohortLines = 8
+
+// cohortsChart renders the retention curves of the most recent cohorts that have been followed for at least two
+// seasons, along with the average of every cohort.
+func cohortsChart(c *stats.Cohorts) template.HTML {
+	seasons := []string{"Freshman", "Sophomore", "Junior", "Senior", "Super Senior"}
+
+	retentionSeries := func(name string, retention []*stats.Retention) svgchart.Series {
+		series := svgchart.Series{Name: name}
+		for _, r := range retention {
+			if r.Observed {
+				series.Values = append(series.Values, r.Percent)
+			} else {
+				series.Values = append(series.Values, math.NaN())
+			}
+		}
+		return series
+	}
+
+	recent := make([]svgchart.Series, 0, maxCohortLines)
+	for i := len(c.Cohorts) - 1; i >= 0 && len(recent) < maxCohortLines; i-- {
+		cohort := c.Cohorts[i]
+		if len(cohort.Retention) < 2 || !cohort.Retention[1].Observed {
+			continue
+		}
+		recent = append(recent, retentionSeries(fmt.Sprintf("%d (%d)", cohort.Year, cohort.Size), cohort.Retention))
+	}
+
+	// oldest first, with the average leading in the section leader color
+	series := []svgchart.Series{retentionSeries("Average", c.Average)}
+	for i := len(recent) - 1; i >= 0; i-- {
+		series = append(series, recent[i])
+	}
+	colors := []string{svgchart.Palette[5], "#f9beff", "#3bcaef", "#62bb47", "#ed3742", "#ba55d3", "#ffffff", "#ff9f43", "#8395a7"}
+
+	return (&svgchart.LineChart{
+		Title:       "Cohort Retention",
+		Description: "The percent of each freshman cohort that marched in each of their following seasons",
+		Labels:      seasons[:len(c.Average)],
+		Series:      series,
+		Colors:      colors,
+		Max:         100,
+		Unit:        "%",
+		Width:       900,
+		Height:      400,
+	}).SVG()
+}
@@ -11,9 +11,16 @@ import (
 	"strings"
 	"time"
 
+	"github.com/purdoobahs/purdoobahs.com/internal/achievements"
+	"github.com/purdoobahs/purdoobahs.com/internal/alumni"
+	"github.com/purdoobahs/purdoobahs.com/internal/cravers"
 	"github.com/purdoobahs/purdoobahs.com/internal/httpheader"
+	"github.com/purdoobahs/purdoobahs.com/internal/leaders"
 	"github.com/purdoobahs/purdoobahs.com/internal/mimetype"
+	"github.com/purdoobahs/purdoobahs.com/internal/openapi"
 	"github.com/purdoobahs/purdoobahs.com/internal/purdoobahs"
+	"github.com/purdoobahs/purdoobahs.com/internal/rsvp"
+	"github.com/purdoobahs/purdoobahs.com/internal/stats"
 	"github.com/purdoobahs/purdoobahs.com/internal/traditions"
 
 	"github.com/goddtriffin/fontawesome"
@@ -33,6 +40,31 @@ type templateData struct {
 	Year            int
 	Traditions      []*traditions.Tradition
 	TraditionByName *traditions.Tradition
+	OpenAPI         *openapi.Document
+	Stats           *stats.Stats
+	Cohorts         *stats.Cohorts
+	Charts          map[string]template.HTML
+	Hometowns       []*hometown
+	Leaders         []*leaders.Year
+	Badges          []*achievements.Badge
+	Events          *eventSchedule
+	Event           *eventView
+	Gallery         *galleryView
+	SectionPhoto    *sectionPhotoView
+	SectionImage    string
+	RSVPStatus      rsvp.Status
+	Directory       *alumni.Directory
+	Games           []*toobahsassinsGame
+	Game            *toobahsassinsGame
+	Cravers         []*cravers.Craver
+	Applications    []*cravers.Application
+	Form            *form
+
+	// CSRFToken has to be sent back by every form
+	CSRFToken string
+
+	// FormToken has to be sent back by every public form, to keep bots out
+	FormToken string
 }
 
 // layout / page / partial
@@ -49,6 +81,12 @@ type (
 		Keywords     []string
 		ThemeColor   string
 		SocialImage  string
+
+		// SocialCard is generated as the SocialImage of pages about a single Purdoobah, section, or tradition
+		SocialCard *pageSocialCard
+
+		// Refresh reloads the page every so many seconds, if set
+		Refresh int
 	}
 
 	header struct {
@@ -60,6 +98,13 @@ type (
 		Copyright copyright
 	}
 
+	// form is a submitted form's values and what's wrong with them, by field name
+	form struct {
+		Values    map[string]string
+		Errors    map[string]string
+		Submitted bool
+	}
+
 	page struct {
 		DisplayName string
 		URL         string
@@ -170,6 +215,9 @@ func (app *application) newTemplateCache() (map[string]*template.Template, error
 		"prettyIntSlice": prettyIntSlice,
 		"prettyStrSlice": prettyStrSlice,
 		"cacheBuster":    app.cacheBuster.Get,
+		"srcset":         app.srcset,
+		"placeholderURL": placeholderURL,
+		"schemaType":     openapi.TypeOf,
 	}
 
 	cache := map[string]*template.Template{}
@@ -208,7 +256,7 @@ func (app *application) render(w http.ResponseWriter, r *http.Request, name stri
 
 	ts, ok := app.templateCache[name]
 	if !ok {
-		app.serveError(w, fmt.Errorf("the template %s does not exist", name))
+		app.serveError(w, r, fmt.Errorf("the template %s does not exist", name))
 		return
 	}
 
@@ -216,12 +264,12 @@ func (app *application) render(w http.ResponseWriter, r *http.Request, name stri
 	buf := new(bytes.Buffer)
 	err := ts.Execute(buf, app.addDefaultData(td))
 	if err != nil {
-		app.serveError(w, err)
+		app.serveError(w, r, err)
 		return
 	}
 	_, err = buf.WriteTo(w)
 	if err != nil {
-		app.serveError(w, err)
+		app.serveError(w, r, err)
 		return
 	}
 }
@@ -277,6 +325,10 @@ func (app *application) addDefaultData(td *templateData) *templateData {
 		td.Metadata.ThemeColor = "#c28e0e"
 	}
 
+	if td.Metadata.SocialImage == "" && td.Metadata.SocialCard != nil {
+		td.Metadata.SocialImage = td.Metadata.SocialCard.URL()
+	}
+
 	if td.Metadata.SocialImage == "" {
 		td.Metadata.SocialImage = app.cacheBuster.Get("/static/image/socials/purdoobahs.webp")
 	}
@@ -0,0 +1,201 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/purdoobahs/purdoobahs.com/internal/purdoobahs"
+	"github.com/purdoobahs/purdoobahs.com/internal/toobahsassins"
+)
+
+// toobahsassinsGame is a game with its players' Purdoobahs looked up, for rendering.
+type toobahsassinsGame struct {
+	Game         *toobahsassins.Game
+	Victor       *purdoobahs.Purdoobah
+	Leaderboard  []*toobahsassinsPlayer
+	Eliminations []*toobahsassinsElimination
+}
+
+type toobahsassinsPlayer struct {
+	Rank      int
+	Player    *toobahsassins.Player
+	Purdoobah *purdoobahs.Purdoobah
+
+	// Target is only filled in for the admin UI.
+	Target *purdoobahs.Purdoobah
+}
+
+type toobahsassinsElimination struct {
+	Elimination *toobahsassins.Elimination
+	Assassin    *purdoobahs.Purdoobah
+	Victim      *purdoobahs.Purdoobah
+}
+
+// toobahsassinsGames looks up every player of the given games. Targets are only looked up when `withTargets` is set.
+func (app *application) toobahsassinsGames(games []*toobahsassins.Game, withTargets bool) []*toobahsassinsGame {
+	views := make([]*toobahsassinsGame, 0, len(games))
+	for _, g := range games {
+		view := &toobahsassinsGame{Game: g}
+		if g.IsOver() {
+			view.Victor = app.toobahsassinsPurdoobah(g.Victor)
+		}
+
+		for i, player := range g.Leaderboard() {
+			p := &toobahsassinsPlayer{
+				Rank:      i + 1,
+				Player:    player,
+				Purdoobah: app.toobahsassinsPurdoobah(player.ID),
+			}
+			if withTargets && player.Target != "" {
+				p.Target = app.toobahsassinsPurdoobah(player.Target)
+			}
+			view.Leaderboard = append(view.Leaderboard, p)
+		}
+
+		// newest first
+		for i := len(g.Eliminations) - 1; i >= 0; i-- {
+			e := g.Eliminations[i]
+			view.Eliminations = append(view.Eliminations, &toobahsassinsElimination{
+				Elimination: e,
+				Assassin:    app.toobahsassinsPurdoobah(e.Assassin),
+				Victim:      app.toobahsassinsPurdoobah(e.Victim),
+			})
+		}
+
+		views = append(views, view)
+	}
+	return views
+}
+
+// toobahsassinsPurdoobah returns the player's Purdoobah, or a stand-in named after their ID if their file has since
+// been removed, so that old games can still be shown.
+func (app *application) toobahsassinsPurdoobah(id string) *purdoobahs.Purdoobah {
+	p, err := app.purdoobahService.ByName(id)
+	if err != nil {
+		return &purdoobahs.Purdoobah{ID: id, Name: id}
+	}
+	return p
+}
+
+// spoonsassinsPurdoobahService hands out Purdoobahs along with the Spoonsassins victories they've won in Toobahsassins
+// games.
+//
+// Games are kept under DATA_DIR, so victories are derived from them whenever a Purdoobah is read instead of being
+// written into asset files (which are baked into every deploy).
+type spoonsassinsPurdoobahService struct {
+	purdoobahs.IPurdoobahService
+	games toobahsassins.IGameService
+}
+
+func (ss *spoonsassinsPurdoobahService) All() ([]*purdoobahs.Purdoobah, error) {
+	all, err := ss.IPurdoobahService.All()
+	if err != nil {
+		return all, err
+	}
+	return all, ss.addVictories(all)
+}
+
+func (ss *spoonsassinsPurdoobahService) ByName(name string) (*purdoobahs.Purdoobah, error) {
+	p, err := ss.IPurdoobahService.ByName(name)
+	if err != nil {
+		return p, err
+	}
+
+	victories, err := ss.games.Victories()
+	if err != nil {
+		return &purdoobahs.Purdoobah{}, err
+	}
+	return p.WithSpoonsassinsVictories(victories[p.ID]), nil
+}
+
+func (ss *spoonsassinsPurdoobahService) CurrentSection() (*purdoobahs.Section, error) {
+	section, err := ss.IPurdoobahService.CurrentSection()
+	if err != nil {
+		return section, err
+	}
+
+	for _, members := range [][]*purdoobahs.Purdoobah{
+		section.StudentLeaders,
+		section.SuperSeniors,
+		section.Seniors,
+		section.Juniors,
+		section.Sophomores,
+		section.Freshmen,
+	} {
+		err = ss.addVictories(members)
+		if err != nil {
+			return &purdoobahs.Section{}, err
+		}
+	}
+	return section, nil
+}
+
+func (ss *spoonsassinsPurdoobahService) SectionByYear(targetYear int) ([]*purdoobahs.Purdoobah, error) {
+	section, err := ss.IPurdoobahService.SectionByYear(targetYear)
+	if err != nil {
+		return section, err
+	}
+	return section, ss.addVictories(section)
+}
+
+// addVictories swaps every Purdoobah in the slice for one with their Toobahsassins victories.
+func (ss *spoonsassinsPurdoobahService) addVictories(ps []*purdoobahs.Purdoobah) error {
+	victories, err := ss.games.Victories()
+	if err != nil {
+		return err
+	}
+	for i, p := range ps {
+		ps[i] = p.WithSpoonsassinsVictories(victories[p.ID])
+	}
+	return nil
+}
+
+// reportSpoonsassinsVictories logs the Toobahsassins victories that are missing from their victor's asset file, so that
+// they can be added by hand.
+func (app *application) reportSpoonsassinsVictories() error {
+	victories, err := app.gameService.Victories()
+	if err != nil {
+		return err
+	}
+
+	ids := make([]string, 0, len(victories))
+	for id := range victories {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	for _, id := range ids {
+		p, err := app.purdoobahService.ByName(id)
+		if errors.Is(err, purdoobahs.ErrPurdoobahNotFound) {
+			app.logger.Error(fmt.Sprintf("Toobahsassins victor no longer exists: `%s`", id))
+			continue
+		} else if err != nil {
+			return err
+		}
+
+		var missing []int
+		for _, season := range victories[id] {
+			if !containsInt(p.Achievements.SpoonsassinsVictories, season) {
+				missing = append(missing, season)
+			}
+		}
+		if len(missing) > 0 {
+			app.logger.Info(fmt.Sprintf(
+				"`%s`'s asset file is missing Spoonsassins victories won in Toobahsassins games: %s",
+				id, prettyIntSlice(missing),
+			))
+		}
+	}
+
+	return nil
+}
+
+func containsInt(s []int, target int) bool {
+	for _, v := range s {
+		if v == target {
+			return true
+		}
+	}
+	return false
+}
@@ -3,16 +3,18 @@ module github.com/purdoobahs/purdoobahs.com
 go 1.18
 
 require (
+	github.com/go-fonts/liberation v0.2.0
 	github.com/goddtriffin/fontawesome v1.0.2
 	github.com/goddtriffin/helmet v1.0.2
-	github.com/gorilla/handlers v1.5.1
 	github.com/gorilla/mux v1.8.0
+	github.com/graphql-go/graphql v0.8.1
 	github.com/justinas/alice v1.2.0
 	github.com/xeipuuv/gojsonschema v1.2.0
+	golang.org/x/image v0.5.0
 )
 
 require (
-	github.com/felixge/httpsnoop v1.0.1 // indirect
 	github.com/xeipuuv/gojsonpointer v0.0.0-20180127040702-4e3ac2762d5f // indirect
 	github.com/xeipuuv/gojsonreference v0.0.0-20180127040603-bd5ef7bd5415 // indirect
+	golang.org/x/text v0.7.0 // indirect
 )
@@ -0,0 +1,116 @@
+package achievements
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/purdoobahs/purdoobahs.com/internal/purdoobahs"
+)
+
+// ErrAchievementNotFound is returned when no achievement exists with the requested ID.
+var ErrAchievementNotFound = errors.New("no achievement exists with that ID")
+
+// IAchievementService defines an Achievement Service
+type IAchievementService interface {
+	All() ([]*Achievement, error)
+	ByID(string) (*Achievement, error)
+}
+
+// Achievement is an honor a Purdoobah can hold, as registered in the achievements assets.
+type Achievement struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Icon        Icon   `json:"icon"`
+
+	// PerYear achievements are earned once per year (e.g. winning a game), rather than once ever.
+	PerYear bool `json:"per_year"`
+}
+
+// Icon is a Font Awesome icon.
+type Icon struct {
+	Prefix string `json:"prefix"`
+	Name   string `json:"name"`
+}
+
+// Holder is a Purdoobah holding an achievement.
+type Holder struct {
+	Purdoobah *purdoobahs.Purdoobah `json:"purdoobah"`
+
+	// Years are the years the achievement was earned, if it's earned per year.
+	Years []int `json:"years,omitempty"`
+}
+
+// Badge is an achievement held by a single Purdoobah.
+type Badge struct {
+	Achievement *Achievement
+	Years       []int
+}
+
+// Holders returns every Purdoobah holding the achievement, in the order given.
+func (a *Achievement) Holders(allPurdoobahs []*purdoobahs.Purdoobah) []*Holder {
+	holders := make([]*Holder, 0)
+	for _, p := range allPurdoobahs {
+		if years, held := p.Achievement(a.ID); held {
+			holders = append(holders, &Holder{Purdoobah: p, Years: years})
+		}
+	}
+	return holders
+}
+
+// Badges returns the achievements held by the Purdoobah, in the order given.
+func Badges(allAchievements []*Achievement, p *purdoobahs.Purdoobah) []*Badge {
+	badges := make([]*Badge, 0)
+	for _, a := range allAchievements {
+		if years, held := p.Achievement(a.ID); held {
+			badges = append(badges, &Badge{Achievement: a, Years: years})
+		}
+	}
+	return badges
+}
+
+// Validate makes sure that every built-in achievement is registered, and that every Purdoobah's honors are registered
+// and held the way they're earned: with a list of years if they're earned per year, and as `true` otherwise.
+func Validate(registry map[string]*Achievement, allPurdoobahs []*purdoobahs.Purdoobah) error {
+	for _, id := range purdoobahs.BuiltInAchievements() {
+		if _, ok := registry[id]; !ok {
+			return fmt.Errorf("built-in achievement isn't registered: `%s`", id)
+		}
+	}
+
+	for _, p := range allPurdoobahs {
+		for id, honor := range p.Achievements.Honors {
+			a, ok := registry[id]
+			if !ok {
+				return fmt.Errorf("Purdoobah `%s` holds an unregistered achievement: `%s`", p.ID, id)
+			}
+			if purdoobahs.IsBuiltInAchievement(id) {
+				return fmt.Errorf("Purdoobah `%s` holds `%s` as an honor instead of in its own field", p.ID, id)
+			}
+			if a.PerYear && len(honor.Years) == 0 {
+				return fmt.Errorf("Purdoobah `%s` has to list the years they earned `%s`", p.ID, id)
+			}
+			if !a.PerYear && len(honor.Years) > 0 {
+				return fmt.Errorf("Purdoobah `%s` can't list years for `%s`, as it isn't earned per year", p.ID, id)
+			}
+		}
+	}
+
+	return nil
+}
+
+// ByName sorts Achievements by name
+type ByName []*Achievement
+
+func (a ByName) Len() int {
+	return len(a)
+}
+
+func (a ByName) Swap(i, j int) {
+	a[i], a[j] = a[j], a[i]
+}
+
+func (a ByName) Less(i, j int) bool {
+	return strings.Compare(a[i].Name, a[j].Name) < 0
+}
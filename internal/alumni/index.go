@@ -0,0 +1,124 @@
+package alumni
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/purdoobahs/purdoobahs.com/internal/purdoobahs"
+)
+
+// Filter narrows down the directory. Every field is optional, and they're combined with AND.
+type Filter struct {
+	Industry       string `json:"industry,omitempty"`
+	GraduationYear int    `json:"graduation_year,omitempty"`
+
+	// State matches the state of where an alumnus currently lives.
+	State string `json:"state,omitempty"`
+}
+
+// IsEmpty returns whether the filter lets every alumnus through.
+func (f *Filter) IsEmpty() bool {
+	return f.Industry == "" && f.GraduationYear == 0 && f.State == ""
+}
+
+// Entry is a single alumnus in the directory.
+type Entry struct {
+	Purdoobah *purdoobahs.Purdoobah `json:"purdoobah"`
+
+	GraduationYear int `json:"graduation_year,omitempty"`
+
+	// GraduationYearEstimated is true when the graduation year wasn't recorded, and was estimated from the years marched.
+	GraduationYearEstimated bool `json:"graduation_year_estimated"`
+}
+
+// Directory is every alumnus matching a filter, along with the values each filter can take.
+type Directory struct {
+	Filter  *Filter  `json:"filter"`
+	Alumni  []*Entry `json:"alumni"`
+	Options *Options `json:"options"`
+}
+
+// Options are the values each filter can take, across every alumnus (not just the filtered ones).
+type Options struct {
+	Industries      []string `json:"industries"`
+	GraduationYears []int    `json:"graduation_years"`
+	States          []string `json:"states"`
+}
+
+// NewDirectory lists every alumnus matching the filter, sorted by name. Only what alumni agreed to share is taken into
+// account, as everything else was redacted when they were loaded.
+func NewDirectory(purdoobahService purdoobahs.IPurdoobahService, filter *Filter) (*Directory, error) {
+	allPurdoobahs, err := purdoobahService.All()
+	if err != nil {
+		return &Directory{}, err
+	}
+
+	d := &Directory{
+		Filter:  filter,
+		Alumni:  make([]*Entry, 0),
+		Options: &Options{},
+	}
+
+	// use Maps like Sets
+	industries := make(map[string]string)
+	graduationYears := make(map[int]bool)
+	states := make(map[string]string)
+
+	for _, p := range allPurdoobahs {
+		if !p.IsYear(purdoobahs.Alumni) {
+			continue
+		}
+
+		entry := &Entry{Purdoobah: p}
+		entry.GraduationYear, entry.GraduationYearEstimated = p.GraduationYear()
+
+		// remember the options, de-duplicated ignoring case
+		if industry := strings.TrimSpace(p.Alumni.Industry); industry != "" {
+			industries[strings.ToLower(industry)] = industry
+		}
+		if entry.GraduationYear != 0 {
+			graduationYears[entry.GraduationYear] = true
+		}
+		if state := strings.TrimSpace(p.Alumni.Location.State); state != "" {
+			states[strings.ToLower(state)] = state
+		}
+
+		if !filter.matches(entry) {
+			continue
+		}
+		d.Alumni = append(d.Alumni, entry)
+	}
+
+	d.Options.Industries = sortedValues(industries)
+	d.Options.States = sortedValues(states)
+	d.Options.GraduationYears = make([]int, 0, len(graduationYears))
+	for year := range graduationYears {
+		d.Options.GraduationYears = append(d.Options.GraduationYears, year)
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(d.Options.GraduationYears)))
+
+	return d, nil
+}
+
+func (f *Filter) matches(entry *Entry) bool {
+	p := entry.Purdoobah
+	if f.Industry != "" && !strings.EqualFold(strings.TrimSpace(p.Alumni.Industry), f.Industry) {
+		return false
+	}
+	if f.GraduationYear != 0 && entry.GraduationYear != f.GraduationYear {
+		return false
+	}
+	if f.State != "" && !strings.EqualFold(strings.TrimSpace(p.Alumni.Location.State), f.State) {
+		return false
+	}
+	return true
+}
+
+func sortedValues(m map[string]string) []string {
+	values := make([]string, 0, len(m))
+	for _, v := range m {
+		values = append(values, v)
+	}
+	sort.Strings(values)
+	return values
+}
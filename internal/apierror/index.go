@@ -0,0 +1,120 @@
+package apierror
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// Code is a stable, machine-readable identifier for a class of API error.
+type Code string
+
+func (c Code) String() string {
+	return string(c)
+}
+
+// List of API error codes.
+const (
+	CodeBadRequest       Code = "bad_request"
+	CodeUnauthorized     Code = "unauthorized"
+	CodeForbidden        Code = "forbidden"
+	CodeNotFound         Code = "not_found"
+	CodeMethodNotAllowed Code = "method_not_allowed"
+	CodeNotAcceptable    Code = "not_acceptable"
+	CodeGone             Code = "gone"
+	CodeTooLarge         Code = "request_too_large"
+	CodeTooManyRequests  Code = "too_many_requests"
+	CodeInternal         Code = "internal_error"
+)
+
+// statusCodes maps HTTP statuses to the API error code that describes them.
+var statusCodes = map[int]Code{
+	http.StatusBadRequest:            CodeBadRequest,
+	http.StatusUnauthorized:          CodeUnauthorized,
+	http.StatusForbidden:             CodeForbidden,
+	http.StatusNotFound:              CodeNotFound,
+	http.StatusMethodNotAllowed:      CodeMethodNotAllowed,
+	http.StatusNotAcceptable:         CodeNotAcceptable,
+	http.StatusGone:                  CodeGone,
+	http.StatusRequestEntityTooLarge: CodeTooLarge,
+	http.StatusTooManyRequests:       CodeTooManyRequests,
+	http.StatusInternalServerError:   CodeInternal,
+}
+
+// CodeOf returns the API error code of the given HTTP status. Statuses without a code of their own fall back to the
+// code of their class (bad_request for 4xx, internal_error for everything else).
+func CodeOf(status int) Code {
+	if code, ok := statusCodes[status]; ok {
+		return code
+	}
+	if status >= 400 && status < 500 {
+		return CodeBadRequest
+	}
+	return CodeInternal
+}
+
+// Envelope is the top-level JSON document of every API error response.
+//
+// e.g. { "error": { "status": 404, "code": "not_found", "message": "...", "request_id": "..." } }
+type Envelope struct {
+	Error *Error `json:"error"`
+}
+
+// Error is a single API error.
+type Error struct {
+	Status    int               `json:"status"`
+	Code      Code              `json:"code"`
+	Message   string            `json:"message"`
+	Details   map[string]string `json:"details,omitempty"`
+	RequestID string            `json:"request_id,omitempty"`
+}
+
+func New(status int, code Code, message string) *Error {
+	return &Error{
+		Status:  status,
+		Code:    code,
+		Message: message,
+	}
+}
+
+// FromStatus creates an Error of the given HTTP status, with its standard code and text.
+func FromStatus(status int) *Error {
+	return New(status, CodeOf(status), http.StatusText(status))
+}
+
+func BadRequest(message string) *Error {
+	return New(http.StatusBadRequest, CodeBadRequest, message)
+}
+
+func NotFound(message string) *Error {
+	return New(http.StatusNotFound, CodeNotFound, message)
+}
+
+func MethodNotAllowed(method string) *Error {
+	return New(http.StatusMethodNotAllowed, CodeMethodNotAllowed, fmt.Sprintf("method %s is not allowed", method))
+}
+
+func NotAcceptable(message string) *Error {
+	return New(http.StatusNotAcceptable, CodeNotAcceptable, message)
+}
+
+func TooManyRequests() *Error {
+	return New(http.StatusTooManyRequests, CodeTooManyRequests, "rate limit exceeded, check the Retry-After header")
+}
+
+// Internal deliberately hides the underlying error from API consumers; it belongs in the logs.
+func Internal() *Error {
+	return New(http.StatusInternalServerError, CodeInternal, http.StatusText(http.StatusInternalServerError))
+}
+
+// WithDetail adds a key/value pair of extra context to the Error.
+func (e *Error) WithDetail(key, value string) *Error {
+	if e.Details == nil {
+		e.Details = make(map[string]string)
+	}
+	e.Details[key] = value
+	return e
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%d %s: %s", e.Status, e.Code, e.Message)
+}
@@ -16,6 +16,9 @@ type CacheBuster struct {
 	cache     map[string]string
 	cacheKeys []string
 
+	// hashes is a mapping of hashed static asset file name to its hash
+	hashes map[string]string
+
 	// staticAssetsRootDirectoryPath is the path to the bin directory where the static assets are copied to
 	staticAssetsRootDirectoryPath string
 
@@ -29,6 +32,7 @@ func NewCacheBuster(staticAssetsRootDirectoryPath string, staticAssetsSubdirecto
 	cb := &CacheBuster{
 		cache:                         make(map[string]string),
 		cacheKeys:                     []string{},
+		hashes:                        make(map[string]string),
 		staticAssetsRootDirectoryPath: staticAssetsRootDirectoryPath,
 		staticAssetsSubdirectoryPaths: staticAssetsSubdirectoryPaths,
 		Debug:                         false,
@@ -60,6 +64,24 @@ func (cb *CacheBuster) Get(path string) string {
 	return ""
 }
 
+// Hash takes a path returned by Get and returns the unique hash of its file's contents, or false if it isn't one.
+//
+// e.g. "/static/image/favicon/favicon.66189abc248d80832e458ee37e93c9e8.ico" -> "66189abc248d80832e458ee37e93c9e8"
+func (cb *CacheBuster) Hash(hashedFilepath string) (string, bool) {
+	hash, ok := cb.hashes[hashedFilepath]
+	return hash, ok
+}
+
+// IsCurrentHash returns whether the given hash is the hash of one of the static assets' contents, as returned by Hash.
+func (cb *CacheBuster) IsCurrentHash(hash string) bool {
+	for _, h := range cb.hashes {
+		if h == hash {
+			return true
+		}
+	}
+	return false
+}
+
 // Add takes a path from root domain to a static asset (as it would be called from a browser, so with a leading slash),
 // generates a unique hash for that file, renames it on disk, and stores the uniquely-hashed filepaths in a cache for
 // lookup later.
@@ -83,6 +105,7 @@ func (cb *CacheBuster) Add(nonHashedFilepath string) error {
 	// store the hashed name in the cache
 	cb.cacheKeys = append(cb.cacheKeys, nonHashedFilepath)
 	cb.cache[nonHashedFilepath] = hashedFilepath
+	cb.hashes[hashedFilepath] = hash
 
 	// rename the file to the new name
 	// the files can't be prepended by slashes, as that would point to the root directory of the computer as opposed to
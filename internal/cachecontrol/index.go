@@ -116,9 +116,7 @@ func (cc *CacheControl) setNoCache(w http.ResponseWriter, r *http.Request) {
 	cc.removeETagHeaders(w, r)
 
 	// set NoCache headers
-	for httpHeaderKey, httpHeaderValue := range noCacheHeaders {
-		w.Header().Set(httpHeaderKey, httpHeaderValue)
-	}
+	SetNoCache(w)
 }
 
 func (cc *CacheControl) removeETagHeaders(w http.ResponseWriter, r *http.Request) {
@@ -128,3 +126,30 @@ func (cc *CacheControl) removeETagHeaders(w http.ResponseWriter, r *http.Request
 		}
 	}
 }
+
+// SetNoCache overrides whatever caching has been set up for the response, so that it isn't cached in any way. It's for
+// responses that mustn't be kept even though their route is cached forever (e.g. errors).
+func SetNoCache(w http.ResponseWriter) {
+	for httpHeaderKey, httpHeaderValue := range noCacheHeaders {
+		w.Header().Set(httpHeaderKey, httpHeaderValue)
+	}
+}
+
+// Vary adds the given request headers to the response's Vary header, so that caches store a separate response for
+// every value of them. Headers already listed are not repeated.
+func Vary(w http.ResponseWriter, headers ...string) {
+	listed := make(map[string]bool)
+	for _, value := range w.Header().Values(httpheader.Vary.String()) {
+		for _, header := range strings.Split(value, ",") {
+			listed[strings.ToLower(strings.TrimSpace(header))] = true
+		}
+	}
+
+	for _, header := range headers {
+		if listed[strings.ToLower(header)] {
+			continue
+		}
+		w.Header().Add(httpheader.Vary.String(), header)
+		listed[strings.ToLower(header)] = true
+	}
+}
@@ -0,0 +1,140 @@
+package contentnegotiation
+
+import (
+	"errors"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/purdoobahs/purdoobahs.com/internal/httpheader"
+)
+
+var (
+	// ErrUnknownFormat is returned when the `?format=` query parameter names a format that isn't offered.
+	ErrUnknownFormat = errors.New("unknown format")
+
+	// ErrNotAcceptable is returned when there aren't any formats to offer.
+	ErrNotAcceptable = errors.New("none of the available formats are acceptable")
+)
+
+// Offer is a representation of a resource that the server is able to produce.
+type Offer struct {
+	// Format is the short name used to request this representation with the `?format=` query parameter (e.g. "csv").
+	Format string
+
+	// MimeType is the media type matched against the Accept header (e.g. "text/csv").
+	MimeType string
+}
+
+// MediaRange is a single entry of an Accept header.
+type MediaRange struct {
+	Type    string
+	Subtype string
+	Q       float64
+}
+
+// Negotiate picks the offer that best satisfies the request.
+//
+// The `?format=` query parameter takes precedence over the Accept header, so that links can point at a specific
+// representation. The first offer is the default, and is used unless the client names another offer outright: an
+// Accept header that only matches offers through a wildcard (e.g. "*/*" or "text/*"), or doesn't match any at all,
+// gets the default, so that clients without a specific preference keep getting what they always have.
+func Negotiate(r *http.Request, offers []Offer) (Offer, error) {
+	if len(offers) == 0 {
+		return Offer{}, ErrNotAcceptable
+	}
+
+	// explicit override
+	if format := r.URL.Query().Get("format"); format != "" {
+		for _, offer := range offers {
+			if strings.EqualFold(offer.Format, format) {
+				return offer, nil
+			}
+		}
+		return Offer{}, ErrUnknownFormat
+	}
+
+	// no preference
+	accept := strings.Join(r.Header.Values(httpheader.Accept.String()), ",")
+	if strings.TrimSpace(accept) == "" {
+		return offers[0], nil
+	}
+
+	// the offer named by the most preferred media range wins
+	for _, mediaRange := range ParseAccept(accept) {
+		if mediaRange.Q <= 0 {
+			break
+		}
+		if mediaRange.specificity() < 2 {
+			continue
+		}
+		for _, offer := range offers {
+			if mediaRange.Matches(offer.MimeType) {
+				return offer, nil
+			}
+		}
+	}
+
+	return offers[0], nil
+}
+
+// ParseAccept parses an Accept header into its media ranges, ordered from most to least preferred.
+//
+// Ranges are ordered by quality, then by specificity (e.g. "text/csv" is preferred over "text/*" at the same quality).
+// Malformed ranges are skipped.
+func ParseAccept(accept string) []MediaRange {
+	mediaRanges := make([]MediaRange, 0)
+
+	for _, part := range strings.Split(accept, ",") {
+		params := strings.Split(part, ";")
+
+		mediaType := strings.ToLower(strings.TrimSpace(params[0]))
+		typ, subtype, found := strings.Cut(mediaType, "/")
+		if !found || typ == "" || subtype == "" || (typ == "*" && subtype != "*") {
+			continue
+		}
+
+		mediaRange := MediaRange{Type: typ, Subtype: subtype, Q: 1}
+		for _, param := range params[1:] {
+			key, value, _ := strings.Cut(strings.TrimSpace(param), "=")
+			if strings.EqualFold(strings.TrimSpace(key), "q") {
+				q, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+				if err != nil || q < 0 || q > 1 {
+					q = 0
+				}
+				mediaRange.Q = q
+			}
+		}
+
+		mediaRanges = append(mediaRanges, mediaRange)
+	}
+
+	sort.SliceStable(mediaRanges, func(i, j int) bool {
+		if mediaRanges[i].Q != mediaRanges[j].Q {
+			return mediaRanges[i].Q > mediaRanges[j].Q
+		}
+		return mediaRanges[i].specificity() > mediaRanges[j].specificity()
+	})
+
+	return mediaRanges
+}
+
+// Matches returns whether the media range covers the given media type, ignoring any of its parameters.
+func (mr MediaRange) Matches(mimeType string) bool {
+	mimeType, _, _ = strings.Cut(mimeType, ";")
+	typ, subtype, _ := strings.Cut(strings.ToLower(strings.TrimSpace(mimeType)), "/")
+
+	return (mr.Type == "*" || mr.Type == typ) && (mr.Subtype == "*" || mr.Subtype == subtype)
+}
+
+func (mr MediaRange) specificity() int {
+	specificity := 0
+	if mr.Type != "*" {
+		specificity++
+	}
+	if mr.Subtype != "*" {
+		specificity++
+	}
+	return specificity
+}
@@ -0,0 +1,50 @@
+package contentnegotiation
+
+import (
+	"errors"
+	"net/http/httptest"
+	"testing"
+)
+
+var testOffers = []Offer{
+	{Format: "json", MimeType: "application/json"},
+	{Format: "csv", MimeType: "text/csv"},
+	{Format: "vcard", MimeType: "text/vcard"},
+}
+
+func TestNegotiate(t *testing.T) {
+	tests := []struct {
+		name   string
+		target string
+		accept string
+		format string
+		err    error
+	}{
+		{name: "no preference", target: "/", format: "json"},
+		{name: "named offer", target: "/", accept: "text/csv", format: "csv"},
+		{name: "most preferred named offer", target: "/", accept: "text/csv;q=0.5, text/vcard", format: "vcard"},
+		{name: "query parameter over Accept", target: "/?format=csv", accept: "application/json", format: "csv"},
+		{name: "unknown query parameter", target: "/?format=xml", err: ErrUnknownFormat},
+		{name: "nothing matches", target: "/", accept: "text/html", format: "json"},
+		{name: "only a wildcard matches", target: "/", accept: "text/*", format: "json"},
+		{name: "default refused for a wildcard", target: "/", accept: "application/json;q=0, */*;q=0.1", format: "json"},
+		{name: "named offer refused", target: "/", accept: "text/csv;q=0", format: "json"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", test.target, nil)
+			if test.accept != "" {
+				r.Header.Set("Accept", test.accept)
+			}
+
+			offer, err := Negotiate(r, testOffers)
+			if !errors.Is(err, test.err) {
+				t.Fatalf("expected error `%v`, but got `%v`", test.err, err)
+			}
+			if offer.Format != test.format {
+				t.Errorf("expected format `%s`, but got `%s`", test.format, offer.Format)
+			}
+		})
+	}
+}
@@ -0,0 +1,186 @@
+package cors
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/purdoobahs/purdoobahs.com/internal/httpheader"
+)
+
+// wildcard allows any origin, but only for safe (read-only) methods.
+const wildcard = "*"
+
+// safeMethods are the methods a wildcard origin is allowed to use.
+var safeMethods = map[string]bool{
+	http.MethodGet:  true,
+	http.MethodHead: true,
+}
+
+type Cors struct {
+	// Debug toggles debug log lines.
+	Debug bool
+
+	// RoutePrefixes, if non-empty, tells Cors which route prefixes it is allowed to work on.
+	RoutePrefixes []string
+
+	// AllowedOrigins is the list of origins allowed to make cross-origin requests (e.g. "https://example.com").
+	// "*" allows every origin to make read-only (GET/HEAD) requests.
+	AllowedOrigins []string
+
+	// AllowedMethods is the list of methods explicitly allowed origins may use.
+	AllowedMethods []string
+
+	// AllowedHeaders is the list of non-simple request headers cross-origin requests may send.
+	AllowedHeaders []string
+
+	// ExposedHeaders is the list of response headers cross-origin scripts are allowed to read.
+	ExposedHeaders []string
+
+	// MaxAge is how long the result of a preflight request may be cached by the browser.
+	MaxAge time.Duration
+}
+
+func NewCors() *Cors {
+	return &Cors{
+		Debug:          false,
+		RoutePrefixes:  []string{},
+		AllowedOrigins: []string{},
+		AllowedMethods: []string{http.MethodGet, http.MethodHead},
+		AllowedHeaders: []string{},
+		ExposedHeaders: []string{},
+		MaxAge:         0,
+	}
+}
+
+// Handler is an HTTP server middleware which answers CORS preflight requests, and adds the CORS response headers to
+// cross-origin requests from allowed origins.
+func (c *Cors) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !c.matchesRoutePrefix(r) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		// the response differs depending on the requesting origin, so caches need to know
+		w.Header().Add(httpheader.Vary.String(), httpheader.Origin.String())
+
+		origin := r.Header.Get(httpheader.Origin.String())
+		requestMethod := r.Header.Get(httpheader.AccessControlRequestMethod.String())
+
+		// preflight
+		if r.Method == http.MethodOptions && requestMethod != "" {
+			w.Header().Add(httpheader.Vary.String(), httpheader.AccessControlRequestMethod.String())
+			w.Header().Add(httpheader.Vary.String(), httpheader.AccessControlRequestHeaders.String())
+
+			c.preflight(w, r, origin, requestMethod)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		// actual request
+		if allowOrigin, ok := c.allowOrigin(origin, r.Method); ok {
+			w.Header().Set(httpheader.AccessControlAllowOrigin.String(), allowOrigin)
+			if len(c.ExposedHeaders) > 0 {
+				w.Header().Set(httpheader.AccessControlExposeHeaders.String(), strings.Join(c.ExposedHeaders, ", "))
+			}
+		} else if origin != "" && c.Debug {
+			fmt.Printf("CORS origin not allowed: `%s` `%s %s`\n", origin, r.Method, r.URL.RequestURI())
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// preflight sets the CORS headers of a preflight response, but only if the origin, method, and headers are allowed.
+func (c *Cors) preflight(w http.ResponseWriter, r *http.Request, origin, requestMethod string) {
+	allowOrigin, ok := c.allowOrigin(origin, requestMethod)
+	if !ok {
+		if c.Debug {
+			fmt.Printf("CORS preflight rejected: `%s` `%s %s`\n", origin, requestMethod, r.URL.RequestURI())
+		}
+		return
+	}
+
+	// every requested header has to be allowed
+	var requestHeaders []string
+	for _, value := range r.Header.Values(httpheader.AccessControlRequestHeaders.String()) {
+		for _, header := range strings.Split(value, ",") {
+			if header = strings.TrimSpace(header); header != "" {
+				if !c.isAllowedHeader(header) {
+					if c.Debug {
+						fmt.Printf("CORS preflight header not allowed: `%s` `%s`\n", origin, header)
+					}
+					return
+				}
+				requestHeaders = append(requestHeaders, header)
+			}
+		}
+	}
+
+	w.Header().Set(httpheader.AccessControlAllowOrigin.String(), allowOrigin)
+	w.Header().Set(httpheader.AccessControlAllowMethods.String(), requestMethod)
+	if len(requestHeaders) > 0 {
+		w.Header().Set(httpheader.AccessControlAllowHeaders.String(), strings.Join(requestHeaders, ", "))
+	}
+	if c.MaxAge > 0 {
+		w.Header().Set(httpheader.AccessControlMaxAge.String(), strconv.Itoa(int(c.MaxAge.Seconds())))
+	}
+}
+
+// allowOrigin returns the value of the Access-Control-Allow-Origin header for the given origin and method, and whether
+// the request is allowed at all.
+func (c *Cors) allowOrigin(origin, method string) (string, bool) {
+	if origin == "" {
+		return "", false
+	}
+
+	method = strings.ToUpper(method)
+	for _, allowedOrigin := range c.AllowedOrigins {
+		if strings.EqualFold(allowedOrigin, origin) && c.isAllowedMethod(method) {
+			return origin, true
+		}
+	}
+
+	for _, allowedOrigin := range c.AllowedOrigins {
+		if allowedOrigin == wildcard && safeMethods[method] {
+			return wildcard, true
+		}
+	}
+
+	return "", false
+}
+
+func (c *Cors) isAllowedMethod(method string) bool {
+	for _, allowedMethod := range c.AllowedMethods {
+		if strings.EqualFold(allowedMethod, method) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *Cors) isAllowedHeader(header string) bool {
+	for _, allowedHeader := range c.AllowedHeaders {
+		if strings.EqualFold(allowedHeader, header) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *Cors) matchesRoutePrefix(r *http.Request) bool {
+	// if prefixes list is empty, work on every route
+	if len(c.RoutePrefixes) == 0 {
+		return true
+	}
+
+	for _, prefix := range c.RoutePrefixes {
+		if strings.HasPrefix(r.URL.Path, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,69 @@
+package cravers
+
+import (
+	"fmt"
+	"net/mail"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// ApplicationStatus is where an application is in the review queue.
+type ApplicationStatus string
+
+const (
+	Pending  ApplicationStatus = "pending"
+	Approved ApplicationStatus = "approved"
+	Rejected ApplicationStatus = "rejected"
+)
+
+// maxStoryLength keeps a single application from filling up the review queue.
+const maxStoryLength = 5000
+
+// Application is a nomination for the Cravers Hall of Fame, submitted through the website.
+type Application struct {
+	ID          string            `json:"id"`
+	SubmittedAt time.Time         `json:"submitted_at"`
+	Status      ApplicationStatus `json:"status"`
+	ReviewedAt  *time.Time        `json:"reviewed_at,omitempty"`
+
+	// who applied
+	Name  string `json:"name"`
+	Email string `json:"email"`
+
+	// who they're nominating, and why
+	Nominee   string `json:"nominee"`
+	Purdoobah string `json:"purdoobah,omitempty"`
+	Story     string `json:"story"`
+}
+
+// Validate trims the application's fields, and returns a message for every field that needs fixing, by field name.
+func (a *Application) Validate() map[string]string {
+	a.Name = strings.TrimSpace(a.Name)
+	a.Email = strings.TrimSpace(a.Email)
+	a.Nominee = strings.TrimSpace(a.Nominee)
+	a.Purdoobah = strings.TrimSpace(a.Purdoobah)
+	a.Story = strings.TrimSpace(a.Story)
+
+	problems := make(map[string]string)
+	if a.Name == "" {
+		problems["name"] = "Please tell us who you are."
+	}
+	if _, err := mail.ParseAddress(a.Email); err != nil {
+		problems["email"] = "Please enter a valid email address, so that we can get back to you."
+	}
+	if a.Nominee == "" {
+		problems["nominee"] = "Please tell us who you're nominating."
+	}
+	if a.Story == "" {
+		problems["story"] = "Please tell us why they belong in the Hall of Fame."
+	} else if utf8.RuneCountInString(a.Story) > maxStoryLength {
+		problems["story"] = fmt.Sprintf("Please keep it under %d characters.", maxStoryLength)
+	}
+	return problems
+}
+
+// IsValidStatus returns whether the status is one an application can be reviewed as.
+func IsValidStatus(status ApplicationStatus) bool {
+	return status == Approved || status == Rejected || status == Pending
+}
@@ -0,0 +1,63 @@
+package cravers
+
+import (
+	"strings"
+)
+
+// Craver is an inductee of the White Castle Cravers Hall of Fame.
+type Craver struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+
+	// Purdoobah is the ID of the inducted Purdoobah, if a single Purdoobah was inducted (rather than e.g. the whole
+	// section).
+	Purdoobah string `json:"purdoobah,omitempty"`
+
+	Year     int    `json:"year"`
+	Citation string `json:"citation"`
+	Photo    string `json:"photo,omitempty"`
+	Video    string `json:"video,omitempty"`
+
+	Metadata struct {
+		Image struct {
+			File string `json:"file"`
+			Alt  string `json:"alt"`
+
+			// Width and Height are the size of the image in pixels, so that room can be made for it before it loads.
+			Width  int `json:"width"`
+			Height int `json:"height"`
+
+			// DominantColor is the most common color of the image, as a hex code.
+			DominantColor string `json:"dominant_color"`
+
+			// Placeholder is a tiny version of the image, as a data URL, to show blurred while the image loads. It's left
+			// out of lists, where it would add up.
+			Placeholder string `json:"placeholder,omitempty"`
+		} `json:"image"`
+	} `json:"metadata"`
+}
+
+// WithoutPlaceholder returns a copy of this Craver without its image placeholder.
+func (c *Craver) WithoutPlaceholder() *Craver {
+	without := *c
+	without.Metadata.Image.Placeholder = ""
+	return &without
+}
+
+// ByInduction sorts Cravers by the year they were inducted, newest first, then by name
+type ByInduction []*Craver
+
+func (c ByInduction) Len() int {
+	return len(c)
+}
+
+func (c ByInduction) Swap(i, j int) {
+	c[i], c[j] = c[j], c[i]
+}
+
+func (c ByInduction) Less(i, j int) bool {
+	if c[i].Year != c[j].Year {
+		return c[i].Year > c[j].Year
+	}
+	return strings.Compare(c[i].Name, c[j].Name) < 0
+}
@@ -0,0 +1,24 @@
+package cravers
+
+import "errors"
+
+var (
+	// ErrCraverNotFound is returned when no Craver exists with the requested name.
+	ErrCraverNotFound = errors.New("no Craver exists with that name")
+
+	// ErrApplicationNotFound is returned when no application exists with the requested ID.
+	ErrApplicationNotFound = errors.New("no application exists with that ID")
+)
+
+// ICraverService defines a Cravers Hall of Fame Service
+type ICraverService interface {
+	All() ([]*Craver, error)
+	ByName(string) (*Craver, error)
+}
+
+// IApplicationService defines a Cravers Hall of Fame application review queue
+type IApplicationService interface {
+	All() ([]*Application, error)
+	Submit(*Application) (*Application, error)
+	Review(id string, status ApplicationStatus) (*Application, error)
+}
@@ -0,0 +1,163 @@
+package csrf
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+type contextKey string
+
+const tokenContextKey contextKey = "csrfToken"
+
+// tokenLength is the number of random bytes in a token.
+const tokenLength = 32
+
+// safeMethods don't change anything, so they don't need a token.
+var safeMethods = map[string]bool{
+	http.MethodGet:     true,
+	http.MethodHead:    true,
+	http.MethodOptions: true,
+	http.MethodTrace:   true,
+}
+
+type CSRF struct {
+	// Debug toggles debug log lines.
+	Debug bool
+
+	// RoutePrefixes, if non-empty, tells CSRF which route prefixes it is allowed to work on.
+	RoutePrefixes []string
+
+	// CookieName is the name of the cookie holding the client's token.
+	CookieName string
+
+	// FieldName is the name of the form field every unsafe request has to echo the token back in.
+	FieldName string
+
+	// Secure restricts the cookie to HTTPS.
+	Secure bool
+
+	// MaxBodyBytes, if positive, is the most an unsafe request may send. The body has to be read to find the token, so
+	// it's limited here rather than in the handlers behind Protect.
+	MaxBodyBytes int64
+
+	// FailureHandler handles requests whose token is missing or doesn't match.
+	FailureHandler http.Handler
+}
+
+func NewCSRF() *CSRF {
+	return &CSRF{
+		Debug:         false,
+		RoutePrefixes: []string{},
+		CookieName:    "csrf_token",
+		FieldName:     "csrf_token",
+		Secure:        true,
+		MaxBodyBytes:  0,
+		FailureHandler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+		}),
+	}
+}
+
+// Protect is an HTTP server middleware which guards forms against cross-site request forgery using the double-submit
+// cookie pattern: every client is given a random token in a cookie, and every unsafe request has to send the same token
+// back in a form field. Other sites can make a browser send the cookie, but can't read it to fill in the field.
+func (c *CSRF) Protect(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !c.matchesRoutePrefix(r) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		token := ""
+		if cookie, err := r.Cookie(c.CookieName); err == nil && isValidToken(cookie.Value) {
+			token = cookie.Value
+		}
+
+		if !safeMethods[r.Method] {
+			if c.MaxBodyBytes > 0 {
+				r.Body = http.MaxBytesReader(w, r.Body, c.MaxBodyBytes)
+			}
+			err := r.ParseForm()
+			if err != nil {
+				if c.Debug {
+					fmt.Printf("CSRF couldn't parse form: `%s %s`: %s\n", r.Method, r.URL.RequestURI(), err)
+				}
+				http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+				return
+			}
+
+			submitted := r.PostForm.Get(c.FieldName)
+			if token == "" || subtle.ConstantTimeCompare([]byte(token), []byte(submitted)) != 1 {
+				if c.Debug {
+					fmt.Printf("CSRF token mismatch: `%s %s`\n", r.Method, r.URL.RequestURI())
+				}
+				c.FailureHandler.ServeHTTP(w, r)
+				return
+			}
+		}
+
+		// hand out a token to clients that don't have one yet
+		if token == "" {
+			var err error
+			token, err = newToken()
+			if err != nil {
+				http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+				return
+			}
+
+			http.SetCookie(w, &http.Cookie{
+				Name:     c.CookieName,
+				Value:    token,
+				Path:     "/",
+				Secure:   c.Secure,
+				HttpOnly: true,
+				SameSite: http.SameSiteStrictMode,
+			})
+		}
+
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), tokenContextKey, token)))
+	})
+}
+
+// Token returns the request's CSRF token, to be put into a form's hidden field. It's empty if the request didn't pass
+// through Protect.
+func Token(r *http.Request) string {
+	if token, ok := r.Context().Value(tokenContextKey).(string); ok {
+		return token
+	}
+	return ""
+}
+
+func newToken() (string, error) {
+	b := make([]byte, tokenLength)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// isValidToken rejects cookies that can't have been handed out by us, so that a client can't pick its own token.
+func isValidToken(token string) bool {
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	return err == nil && len(b) == tokenLength
+}
+
+func (c *CSRF) matchesRoutePrefix(r *http.Request) bool {
+	// if prefixes list is empty, work on every route
+	if len(c.RoutePrefixes) == 0 {
+		return true
+	}
+
+	for _, prefix := range c.RoutePrefixes {
+		if strings.HasPrefix(r.URL.Path, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,143 @@
+package events
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DefaultTimeZone is the time zone of West Lafayette, which events are in unless they say otherwise.
+const DefaultTimeZone = "America/Indiana/Indianapolis"
+
+const (
+	dateLayout = "2006-01-02"
+	timeLayout = "15:04"
+)
+
+// Event is a date on the section's calendar, e.g. a game day.
+type Event struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+
+	// Opponent is who Purdue is playing, if it's a game day.
+	Opponent string `json:"opponent,omitempty"`
+
+	// Date is the local date of the event (e.g. "2022-09-01").
+	Date string `json:"date"`
+
+	// CallTime is the local time the section has to show up (e.g. "17:30"). Events without one last all day.
+	CallTime string `json:"call_time,omitempty"`
+
+	// EndTime is the local time the event is over, if it's known.
+	EndTime string `json:"end_time,omitempty"`
+
+	// TimeZone is the IANA time zone of the event's date and times; DefaultTimeZone if it's empty.
+	TimeZone string `json:"time_zone,omitempty"`
+
+	Location    string `json:"location"`
+	Description string `json:"description,omitempty"`
+
+	// Traditions are the IDs of the traditions that happen during the event.
+	Traditions []string `json:"traditions,omitempty"`
+
+	// RSVP is set for events that people have to RSVP to (e.g. reunions).
+	RSVP *RSVP `json:"rsvp,omitempty"`
+
+	// Start and End are generated on load from the date, times, and time zone. End is nil if it isn't known.
+	Start  time.Time  `json:"start"`
+	End    *time.Time `json:"end,omitempty"`
+	AllDay bool       `json:"all_day"`
+}
+
+// RSVP is how an event takes RSVPs.
+type RSVP struct {
+	// Capacity is how many people can attend, guests included; 0 means there's no limit. Everyone after that is put
+	// on the waitlist.
+	Capacity int `json:"capacity,omitempty"`
+}
+
+// TakesRSVPs returns whether people can still RSVP to the event.
+func (e *Event) TakesRSVPs(now time.Time) bool {
+	return e.RSVP != nil && e.IsUpcoming(now)
+}
+
+// Schedule generates the event's start and end from its date, times, and time zone.
+func (e *Event) Schedule() error {
+	if e.TimeZone == "" {
+		e.TimeZone = DefaultTimeZone
+	}
+	loc, err := time.LoadLocation(e.TimeZone)
+	if err != nil {
+		return fmt.Errorf("Event `%s` has an unknown time zone: %w", e.ID, err)
+	}
+
+	date, err := time.ParseInLocation(dateLayout, e.Date, loc)
+	if err != nil {
+		return fmt.Errorf("Event `%s` has an invalid date: %w", e.ID, err)
+	}
+
+	// without a call time, the event lasts all day
+	if e.CallTime == "" {
+		end := date.AddDate(0, 0, 1)
+		e.Start = date
+		e.End = &end
+		e.AllDay = true
+		return nil
+	}
+
+	e.Start, err = atTime(date, e.CallTime)
+	if err != nil {
+		return fmt.Errorf("Event `%s` has an invalid call time: %w", e.ID, err)
+	}
+	e.End = nil
+	if e.EndTime != "" {
+		end, err := atTime(date, e.EndTime)
+		if err != nil {
+			return fmt.Errorf("Event `%s` has an invalid end time: %w", e.ID, err)
+		}
+
+		// events that end before they start run past midnight
+		if !end.After(e.Start) {
+			end = end.AddDate(0, 0, 1)
+		}
+		e.End = &end
+	}
+	e.AllDay = false
+
+	return nil
+}
+
+// IsUpcoming returns whether the event hasn't ended yet.
+func (e *Event) IsUpcoming(now time.Time) bool {
+	if e.End != nil {
+		return now.Before(*e.End)
+	}
+	return !now.After(e.Start)
+}
+
+// atTime returns the given local time of day on the date.
+func atTime(date time.Time, clock string) (time.Time, error) {
+	t, err := time.Parse(timeLayout, clock)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Date(date.Year(), date.Month(), date.Day(), t.Hour(), t.Minute(), 0, 0, date.Location()), nil
+}
+
+// ByDate sorts Events by when they start, earliest first, then by name
+type ByDate []*Event
+
+func (e ByDate) Len() int {
+	return len(e)
+}
+
+func (e ByDate) Swap(i, j int) {
+	e[i], e[j] = e[j], e[i]
+}
+
+func (e ByDate) Less(i, j int) bool {
+	if !e[i].Start.Equal(e[j].Start) {
+		return e[i].Start.Before(e[j].Start)
+	}
+	return strings.Compare(e[i].Name, e[j].Name) < 0
+}
@@ -0,0 +1,12 @@
+package events
+
+import "errors"
+
+// ErrEventNotFound is returned when no Event exists with the requested name.
+var ErrEventNotFound = errors.New("no Event exists with that name")
+
+// IEventService defines an Events Service
+type IEventService interface {
+	All() ([]*Event, error)
+	ByName(string) (*Event, error)
+}
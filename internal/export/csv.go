@@ -0,0 +1,121 @@
+package export
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/purdoobahs/purdoobahs.com/internal/purdoobahs"
+)
+
+// csvColumns is the header row of a CSV export. Nested fields are flattened, and lists are joined with semicolons.
+var csvColumns = []string{
+	"id",
+	"name",
+	"first_name",
+	"middle_name",
+	"last_name",
+	"emoji",
+	"years_marched",
+	"shoutout",
+	"major",
+	"minor",
+	"year",
+	"effective_year",
+	"hometown_city",
+	"hometown_state",
+	"job",
+	"employer",
+	"title",
+	"industry",
+	"location_city",
+	"location_state",
+	"graduation_year",
+	"hobbies",
+	"facebook",
+	"instagram",
+	"linkedin",
+	"student_leader",
+	"bottom_feeder_committee",
+	"spoonsassins_victories",
+	"kappa_kappa_psi",
+	"tau_beta_sigma",
+	"image",
+}
+
+// CSV writes the Purdoobahs as RFC 4180 CSV, one row per Purdoobah.
+func CSV(w io.Writer, allPurdoobahs []*purdoobahs.Purdoobah) error {
+	cw := csv.NewWriter(w)
+	cw.UseCRLF = true
+
+	err := cw.Write(csvColumns)
+	if err != nil {
+		return err
+	}
+
+	for _, p := range allPurdoobahs {
+		row := []string{
+			p.ID,
+			p.Name,
+			p.BirthCertificateName.First,
+			p.BirthCertificateName.Middle,
+			p.BirthCertificateName.Last,
+			p.Emoji,
+			joinInts(p.Marching.YearsMarched, ";"),
+			p.Marching.Shoutout,
+			p.Education.Major,
+			p.Education.Minor,
+			string(p.Education.Year),
+			string(p.Education.EffectiveYear),
+			p.Hometown.City,
+			p.Hometown.State,
+			p.Alumni.Job,
+			p.Alumni.Employer,
+			p.Alumni.Title,
+			p.Alumni.Industry,
+			p.Alumni.Location.City,
+			p.Alumni.Location.State,
+			optionalInt(p.Alumni.GraduationYear),
+			strings.Join(p.Personal.Hobbies, ";"),
+			p.Personal.Socials.Facebook,
+			p.Personal.Socials.Instagram,
+			p.Personal.Socials.LinkedIn,
+			joinInts(p.StudentLeaderYears(), ";"),
+			strconv.FormatBool(p.Achievements.BottomFeederCommittee),
+			joinInts(p.Achievements.SpoonsassinsVictories, ";"),
+			strconv.FormatBool(p.Achievements.KappaKappaPsi),
+			strconv.FormatBool(p.Achievements.TauBetaSigma),
+			p.Metadata.Image.File,
+		}
+		for i := range row {
+			row[i] = sanitizeCell(row[i])
+		}
+
+		err = cw.Write(row)
+		if err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// sanitizeCell defuses cells that a spreadsheet would otherwise evaluate as a formula (CSV injection) by prefixing
+// them with an apostrophe. Plain numbers such as -1 are left alone.
+func sanitizeCell(cell string) string {
+	if cell == "" {
+		return cell
+	}
+
+	switch cell[0] {
+	case '=', '+', '@', '\t', '\r':
+		return "'" + cell
+	case '-':
+		if _, err := strconv.ParseFloat(cell, 64); err != nil {
+			return "'" + cell
+		}
+	}
+	return cell
+}
@@ -0,0 +1,34 @@
+package export
+
+import (
+	"bytes"
+	"encoding/csv"
+	"testing"
+
+	"github.com/purdoobahs/purdoobahs.com/internal/purdoobahs"
+)
+
+func TestCSVWritesARowPerPurdoobah(t *testing.T) {
+	var buf bytes.Buffer
+	err := CSV(&buf, []*purdoobahs.Purdoobah{
+		newTestPurdoobah("sharer", "Sharer", true),
+		newTestPurdoobah("private", "=Private", false),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 3 {
+		t.Fatalf("expected a header and 2 rows, but got %d rows", len(rows))
+	}
+	if rows[1][0] != "sharer" || rows[2][0] != "private" {
+		t.Errorf("expected rows in the given order, but got `%s` and `%s`", rows[1][0], rows[2][0])
+	}
+	if rows[2][1] != "'=Private" {
+		t.Errorf("expected a formula to be defused, but got `%s`", rows[2][1])
+	}
+}
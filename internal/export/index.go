@@ -0,0 +1,23 @@
+package export
+
+import (
+	"strconv"
+	"strings"
+)
+
+// joinInts joins a list of integers with the given separator.
+func joinInts(ints []int, sep string) string {
+	s := make([]string, 0, len(ints))
+	for _, i := range ints {
+		s = append(s, strconv.Itoa(i))
+	}
+	return strings.Join(s, sep)
+}
+
+// optionalInt formats an integer, leaving it blank if it's unset.
+func optionalInt(i int) string {
+	if i == 0 {
+		return ""
+	}
+	return strconv.Itoa(i)
+}
@@ -0,0 +1,28 @@
+package export
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/purdoobahs/purdoobahs.com/internal/purdoobahs"
+)
+
+// NDJSON writes the Purdoobahs as newline-delimited JSON, one Purdoobah per line.
+//
+// If w is an http.Flusher, every line is flushed as soon as it's written so that clients can stream the results.
+func NDJSON(w io.Writer, allPurdoobahs []*purdoobahs.Purdoobah) error {
+	flusher, _ := w.(interface{ Flush() })
+
+	encoder := json.NewEncoder(w)
+	for _, p := range allPurdoobahs {
+		err := encoder.Encode(p)
+		if err != nil {
+			return err
+		}
+		if flusher != nil {
+			flusher.Flush()
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,59 @@
+package export
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/purdoobahs/purdoobahs.com/internal/rsvp"
+)
+
+// rsvpColumns is the header row of an attendee list.
+var rsvpColumns = []string{
+	"status",
+	"waitlist_position",
+	"name",
+	"purdoobah",
+	"email",
+	"guests",
+	"party_size",
+	"submitted_at",
+}
+
+// RSVPs writes an event's attendee list as RFC 4180 CSV, in the order the RSVPs were submitted.
+//
+// Every cell was typed in by a visitor, so they're all defused against CSV injection.
+func RSVPs(w io.Writer, entries []*rsvp.Entry) error {
+	cw := csv.NewWriter(w)
+	cw.UseCRLF = true
+
+	err := cw.Write(rsvpColumns)
+	if err != nil {
+		return err
+	}
+
+	for _, e := range entries {
+		row := []string{
+			string(e.Status),
+			optionalInt(e.Position),
+			e.RSVP.Name,
+			e.RSVP.Purdoobah,
+			e.RSVP.Email,
+			strconv.Itoa(e.RSVP.Guests),
+			strconv.Itoa(e.RSVP.PartySize()),
+			e.RSVP.SubmittedAt.UTC().Format(time.RFC3339),
+		}
+		for i := range row {
+			row[i] = sanitizeCell(row[i])
+		}
+
+		err = cw.Write(row)
+		if err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
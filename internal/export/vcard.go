@@ -0,0 +1,116 @@
+package export
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/purdoobahs/purdoobahs.com/internal/purdoobahs"
+)
+
+// maxVCardLineLength is the longest a content line may be, in octets, before it must be folded (RFC 6350 3.2).
+const maxVCardLineLength = 75
+
+// VCard writes the Purdoobahs as vCard 4.0 (RFC 6350) contacts.
+//
+// Only Purdoobahs who have opted in with `personal.share_contact` are written; everyone else is skipped.
+// baseURL is the origin that profile and photo links are made absolute with (e.g. "https://www.purdoobahs.com").
+func VCard(w io.Writer, allPurdoobahs []*purdoobahs.Purdoobah, baseURL string) error {
+	bw := bufio.NewWriter(w)
+
+	for _, p := range allPurdoobahs {
+		if !p.Personal.ShareContact {
+			continue
+		}
+
+		lines := []string{
+			"BEGIN:VCARD",
+			"VERSION:4.0",
+			fmt.Sprintf("UID:%s/purdoobah/%s", baseURL, p.ID),
+			fmt.Sprintf("FN:%s", escapeText(p.FullName())),
+			fmt.Sprintf(
+				"N:%s;%s;%s;;",
+				escapeText(p.BirthCertificateName.Last),
+				escapeText(p.BirthCertificateName.First),
+				escapeText(p.BirthCertificateName.Middle),
+			),
+			fmt.Sprintf("NICKNAME:%s", escapeText(p.Name)),
+			"ORG:Purdue All-American Marching Band;Toobah Section",
+			fmt.Sprintf("URL:%s/purdoobah/%s", baseURL, p.ID),
+		}
+		if p.Metadata.Image.File != "" {
+			lines = append(lines, fmt.Sprintf("PHOTO:%s%s", baseURL, p.Metadata.Image.File))
+		}
+		if jobTitle := p.JobTitle(); jobTitle != "" {
+			lines = append(lines, fmt.Sprintf("TITLE:%s", escapeText(jobTitle)))
+		}
+		for _, social := range []string{
+			p.Personal.Socials.Facebook,
+			p.Personal.Socials.Instagram,
+			p.Personal.Socials.LinkedIn,
+		} {
+			if social != "" {
+				lines = append(lines, fmt.Sprintf("URL;TYPE=social:%s", social))
+			}
+		}
+		if p.Marching.Shoutout != "" {
+			lines = append(lines, fmt.Sprintf("URL;TYPE=shoutout:%s", p.Marching.Shoutout))
+		}
+		lines = append(lines,
+			fmt.Sprintf("NOTE:%s", escapeText(note(p))),
+			"END:VCARD",
+		)
+
+		for _, line := range lines {
+			_, err := bw.WriteString(fold(line))
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return bw.Flush()
+}
+
+// note summarizes the Purdoobah's marching career.
+func note(p *purdoobahs.Purdoobah) string {
+	yearsMarched := joinInts(p.Marching.YearsMarched, ", ")
+	if p.MarchedDuringYear(-1) {
+		yearsMarched = "unknown"
+	}
+	return fmt.Sprintf("Purdoobah (years marched: %s)", yearsMarched)
+}
+
+// escapeText escapes a TEXT property value (RFC 6350 3.4).
+func escapeText(s string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		",", `\,`,
+		";", `\;`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+	).Replace(s)
+}
+
+// fold terminates a content line with CRLF, splitting it into multiple physical lines if it's too long. Lines are
+// never split in the middle of a multi-octet UTF-8 character.
+func fold(line string) string {
+	var sb strings.Builder
+
+	length := 0
+	for _, r := range line {
+		size := utf8.RuneLen(r)
+		if length+size > maxVCardLineLength {
+			sb.WriteString("\r\n ")
+			// the leading space of a continuation line counts towards its length
+			length = 1
+		}
+		sb.WriteRune(r)
+		length += size
+	}
+	sb.WriteString("\r\n")
+
+	return sb.String()
+}
@@ -0,0 +1,61 @@
+package export
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/purdoobahs/purdoobahs.com/internal/purdoobahs"
+)
+
+func newTestPurdoobah(id, name string, shareContact bool) *purdoobahs.Purdoobah {
+	p := &purdoobahs.Purdoobah{ID: id, Name: name}
+	p.BirthCertificateName.First = "Pat"
+	p.BirthCertificateName.Last = "Doe"
+	p.Marching.YearsMarched = []int{2018, 2019}
+	p.Personal.ShareContact = shareContact
+	return p
+}
+
+func TestVCardWritesPurdoobahsWhoShareTheirContact(t *testing.T) {
+	var buf bytes.Buffer
+	err := VCard(&buf, []*purdoobahs.Purdoobah{
+		newTestPurdoobah("sharer", "Sharer", true),
+		newTestPurdoobah("private", "Private", false),
+	}, "https://www.purdoobahs.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	card := buf.String()
+	if card == "" {
+		t.Fatal("expected a vCard, but the export is empty")
+	}
+	if count := strings.Count(card, "BEGIN:VCARD\r\n"); count != 1 {
+		t.Errorf("expected 1 vCard, but got %d", count)
+	}
+	for _, line := range []string{
+		"FN:Pat Doe\r\n",
+		"NICKNAME:Sharer\r\n",
+		"URL:https://www.purdoobahs.com/purdoobah/sharer\r\n",
+		"NOTE:Purdoobah (years marched: 2018\\, 2019)\r\n",
+		"END:VCARD\r\n",
+	} {
+		if !strings.Contains(card, line) {
+			t.Errorf("expected the vCard to contain `%q`:\n%s", line, card)
+		}
+	}
+	if strings.Contains(card, "private") {
+		t.Errorf("expected a Purdoobah who didn't share their contact to be skipped:\n%s", card)
+	}
+}
+
+func TestFoldKeepsLinesShort(t *testing.T) {
+	folded := fold("NOTE:" + strings.Repeat("ü", 50))
+
+	for _, line := range strings.Split(strings.TrimSuffix(folded, "\r\n"), "\r\n") {
+		if len(line) > maxVCardLineLength {
+			t.Errorf("expected lines of at most %d octets, but got %d: `%s`", maxVCardLineLength, len(line), line)
+		}
+	}
+}
@@ -0,0 +1,15 @@
+package gallery
+
+import "errors"
+
+// ErrPhotoNotFound is returned when no Photo exists with the requested name.
+var ErrPhotoNotFound = errors.New("no Photo exists with that name")
+
+// IPhotoService defines a Photo Service
+type IPhotoService interface {
+	All() ([]*Photo, error)
+	ByName(string) (*Photo, error)
+	ByYear(int) ([]*Photo, error)
+	ByTradition(string) ([]*Photo, error)
+	ByPurdoobah(string) ([]*Photo, error)
+}
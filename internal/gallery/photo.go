@@ -0,0 +1,93 @@
+package gallery
+
+import (
+	"strings"
+)
+
+// Photo is a picture in the galleries, as described by its asset file.
+type Photo struct {
+	ID string `json:"id"`
+
+	// File is the path of the full-size photo under /static/image/gallery/.
+	File string `json:"file"`
+
+	Caption string `json:"caption"`
+
+	// Alt describes what's in the photo, for people who can't see it; the caption is used if it's empty.
+	Alt string `json:"alt,omitempty"`
+
+	Photographer string  `json:"photographer"`
+	License      License `json:"license"`
+
+	// Year is the section year the photo was taken in.
+	Year int `json:"year"`
+
+	// Purdoobahs and Traditions are the IDs of who and what is in the photo.
+	Purdoobahs []string `json:"purdoobahs,omitempty"`
+	Traditions []string `json:"traditions,omitempty"`
+
+	Metadata struct {
+		Image struct {
+			File string `json:"file"`
+			Alt  string `json:"alt"`
+		} `json:"image"`
+
+		// Thumbnail is generated from the photo on startup.
+		Thumbnail struct {
+			File   string `json:"file"`
+			Width  int    `json:"width"`
+			Height int    `json:"height"`
+		} `json:"thumbnail"`
+	} `json:"metadata"`
+}
+
+// License is the license a photo is shared under.
+type License struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+}
+
+// DefaultLicense is the license of every photo that doesn't say otherwise, which is the same as the rest of the
+// website's images.
+var DefaultLicense = License{
+	Name: "CC BY-NC-ND 4.0",
+	URL:  "https://creativecommons.org/licenses/by-nc-nd/4.0/",
+}
+
+// HasPurdoobah returns whether the Purdoobah is tagged in the photo.
+func (p *Photo) HasPurdoobah(id string) bool {
+	for _, purdoobah := range p.Purdoobahs {
+		if purdoobah == id {
+			return true
+		}
+	}
+	return false
+}
+
+// HasTradition returns whether the tradition is tagged in the photo.
+func (p *Photo) HasTradition(id string) bool {
+	for _, tradition := range p.Traditions {
+		if tradition == id {
+			return true
+		}
+	}
+	return false
+}
+
+// ByYear sorts Photos by year, newest first, then by name
+type ByYear []*Photo
+
+func (p ByYear) Len() int {
+	return len(p)
+}
+
+func (p ByYear) Swap(i, j int) {
+	p[i], p[j] = p[j], p[i]
+}
+
+func (p ByYear) Less(i, j int) bool {
+	if p[i].Year != p[j].Year {
+		return p[i].Year > p[j].Year
+	}
+	return strings.Compare(p[i].ID, p[j].ID) < 0
+}
@@ -0,0 +1,197 @@
+package gazetteer
+
+import (
+	"bufio"
+	"bytes"
+	_ "embed"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// places is a curated subset of the U.S. Census Bureau's Gazetteer place file, in the same tab-separated format, so
+// that the full national file can be dropped in its place.
+//
+//go:embed places.tsv
+var places []byte
+
+// placeSuffixes are the legal/statistical area descriptions the Census Bureau appends to place names.
+var placeSuffixes = []string{" city", " town", " village", " borough", " township", " CDP", " municipality"}
+
+// Place is a single city (or other populated place) and its location.
+type Place struct {
+	Name  string  `json:"name"`
+	State string  `json:"state"`
+	Lat   float64 `json:"lat"`
+	Lng   float64 `json:"lng"`
+}
+
+// Gazetteer resolves U.S. cities to their location, entirely offline.
+type Gazetteer struct {
+	// places indexes every place by its state's USPS code, then by its normalized name.
+	places map[string]map[string]*Place
+}
+
+// NewGazetteer loads the embedded place file.
+func NewGazetteer() (*Gazetteer, error) {
+	return Load(bytes.NewReader(places))
+}
+
+// Load reads a Census Bureau Gazetteer place file. Only the USPS, NAME, INTPTLAT, and INTPTLONG columns are used, and
+// they may appear in any order.
+func Load(r io.Reader) (*Gazetteer, error) {
+	g := &Gazetteer{places: make(map[string]map[string]*Place)}
+
+	scanner := bufio.NewScanner(r)
+	columns := make(map[string]int)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		fields := strings.Split(scanner.Text(), "\t")
+
+		// header
+		if lineNumber == 1 {
+			for i, field := range fields {
+				columns[strings.ToUpper(strings.TrimSpace(field))] = i
+			}
+			for _, column := range []string{"USPS", "NAME", "INTPTLAT", "INTPTLONG"} {
+				if _, ok := columns[column]; !ok {
+					return &Gazetteer{}, fmt.Errorf("gazetteer is missing the `%s` column", column)
+				}
+			}
+			continue
+		}
+
+		if strings.TrimSpace(scanner.Text()) == "" {
+			continue
+		}
+		field := func(column string) string {
+			if columns[column] >= len(fields) {
+				return ""
+			}
+			return strings.TrimSpace(fields[columns[column]])
+		}
+
+		lat, err := strconv.ParseFloat(field("INTPTLAT"), 64)
+		if err != nil {
+			return &Gazetteer{}, fmt.Errorf("gazetteer line %d has an invalid latitude: `%s`", lineNumber, field("INTPTLAT"))
+		}
+		lng, err := strconv.ParseFloat(field("INTPTLONG"), 64)
+		if err != nil {
+			return &Gazetteer{}, fmt.Errorf("gazetteer line %d has an invalid longitude: `%s`", lineNumber, field("INTPTLONG"))
+		}
+
+		name := field("NAME")
+		for _, suffix := range placeSuffixes {
+			name = strings.TrimSuffix(name, suffix)
+		}
+
+		state := strings.ToUpper(field("USPS"))
+		if g.places[state] == nil {
+			g.places[state] = make(map[string]*Place)
+		}
+		g.places[state][normalize(name)] = &Place{Name: name, State: state, Lat: lat, Lng: lng}
+	}
+	if err := scanner.Err(); err != nil {
+		return &Gazetteer{}, err
+	}
+
+	return g, nil
+}
+
+// Lookup finds a city within a state. The state may be its full name or its USPS code.
+//
+// Names are compared ignoring case, punctuation, and spacing (e.g. "Laporte" matches "La Porte"), and if no name
+// matches exactly, the closest name within a small edit distance is used (e.g. "Pittsburg" matches "Pittsburgh").
+// Returns false if nothing matched, or if more than one place was equally close.
+func (g *Gazetteer) Lookup(city, state string) (*Place, bool) {
+	usps, ok := StateCode(state)
+	if !ok {
+		return &Place{}, false
+	}
+
+	statePlaces := g.places[usps]
+	name := normalize(city)
+	if name == "" {
+		return &Place{}, false
+	}
+
+	// exact
+	if place, ok := statePlaces[name]; ok {
+		return place, true
+	}
+
+	// fuzzy; longer names tolerate more typos
+	maxDistance := 1
+	if len(name) >= 8 {
+		maxDistance = 2
+	}
+
+	var best *Place
+	bestDistance := maxDistance + 1
+	tied := false
+	for candidate, place := range statePlaces {
+		distance := levenshtein(name, candidate)
+		if distance < bestDistance {
+			best, bestDistance, tied = place, distance, false
+		} else if distance == bestDistance {
+			tied = true
+		}
+	}
+	if best == nil || tied {
+		return &Place{}, false
+	}
+
+	return best, true
+}
+
+// normalize lowercases a place name and strips everything but letters and digits, expanding the abbreviation "St."
+// so that e.g. "St. John" and "Saint John" are the same place.
+func normalize(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if strings.HasPrefix(name, "saint ") {
+		name = "st " + strings.TrimPrefix(name, "saint ")
+	}
+
+	var sb strings.Builder
+	for _, r := range name {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String()
+}
+
+// levenshtein is the minimum number of single-character insertions, deletions, and substitutions that turn a into b.
+func levenshtein(a, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = minInt(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+
+	return previous[len(b)]
+}
+
+func minInt(first int, rest ...int) int {
+	m := first
+	for _, i := range rest {
+		if i < m {
+			m = i
+		}
+	}
+	return m
+}
@@ -0,0 +1,76 @@
+package gazetteer
+
+import "strings"
+
+// states maps every state (plus DC and Puerto Rico) to its USPS code.
+var states = map[string]string{
+	"alabama":              "AL",
+	"alaska":               "AK",
+	"arizona":              "AZ",
+	"arkansas":             "AR",
+	"california":           "CA",
+	"colorado":             "CO",
+	"connecticut":          "CT",
+	"delaware":             "DE",
+	"district of columbia": "DC",
+	"florida":              "FL",
+	"georgia":              "GA",
+	"hawaii":               "HI",
+	"idaho":                "ID",
+	"illinois":             "IL",
+	"indiana":              "IN",
+	"iowa":                 "IA",
+	"kansas":               "KS",
+	"kentucky":             "KY",
+	"louisiana":            "LA",
+	"maine":                "ME",
+	"maryland":             "MD",
+	"massachusetts":        "MA",
+	"michigan":             "MI",
+	"minnesota":            "MN",
+	"mississippi":          "MS",
+	"missouri":             "MO",
+	"montana":              "MT",
+	"nebraska":             "NE",
+	"nevada":               "NV",
+	"new hampshire":        "NH",
+	"new jersey":           "NJ",
+	"new mexico":           "NM",
+	"new york":             "NY",
+	"north carolina":       "NC",
+	"north dakota":         "ND",
+	"ohio":                 "OH",
+	"oklahoma":             "OK",
+	"oregon":               "OR",
+	"pennsylvania":         "PA",
+	"puerto rico":          "PR",
+	"rhode island":         "RI",
+	"south carolina":       "SC",
+	"south dakota":         "SD",
+	"tennessee":            "TN",
+	"texas":                "TX",
+	"utah":                 "UT",
+	"vermont":              "VT",
+	"virginia":             "VA",
+	"washington":           "WA",
+	"west virginia":        "WV",
+	"wisconsin":            "WI",
+	"wyoming":              "WY",
+}
+
+// StateCode returns the USPS code of a state given either its full name or its code.
+func StateCode(state string) (string, bool) {
+	state = strings.TrimSpace(state)
+
+	if code, ok := states[strings.ToLower(state)]; ok {
+		return code, true
+	}
+
+	code := strings.ToUpper(state)
+	for _, c := range states {
+		if c == code {
+			return code, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,37 @@
+package geojson
+
+// FeatureCollection is a list of features.
+type FeatureCollection struct {
+	Type     string     `json:"type"`
+	Features []*Feature `json:"features"`
+}
+
+// Feature is a located object along with its properties.
+type Feature struct {
+	Type       string      `json:"type"`
+	Geometry   *Point      `json:"geometry"`
+	Properties interface{} `json:"properties"`
+}
+
+// Point is a single position. Its coordinates are in [longitude, latitude] order.
+type Point struct {
+	Type        string     `json:"type"`
+	Coordinates [2]float64 `json:"coordinates"`
+}
+
+// NewFeatureCollection creates a collection of the given features.
+func NewFeatureCollection(features []*Feature) *FeatureCollection {
+	if features == nil {
+		features = []*Feature{}
+	}
+	return &FeatureCollection{Type: "FeatureCollection", Features: features}
+}
+
+// NewPointFeature creates a feature located at a single point.
+func NewPointFeature(lat, lng float64, properties interface{}) *Feature {
+	return &Feature{
+		Type:       "Feature",
+		Geometry:   &Point{Type: "Point", Coordinates: [2]float64{lng, lat}},
+		Properties: properties,
+	}
+}
@@ -0,0 +1,79 @@
+package graphqlapi
+
+import (
+	"context"
+
+	"github.com/purdoobahs/purdoobahs.com/internal/purdoobahs"
+	"github.com/purdoobahs/purdoobahs.com/internal/traditions"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
+)
+
+type GraphQL struct {
+	// MaxDepth is the deepest a query's selection sets are allowed to nest.
+	MaxDepth int
+
+	// MaxComplexity is the most fields a query is allowed to resolve, with fields under a list counted once per
+	// expected list item.
+	MaxComplexity int
+
+	schema graphql.Schema
+}
+
+// Request is a GraphQL request as sent over HTTP.
+type Request struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName,omitempty"`
+	Variables     map[string]interface{} `json:"variables,omitempty"`
+}
+
+// NewGraphQL creates the GraphQL schema over the given services.
+func NewGraphQL(
+	purdoobahService purdoobahs.IPurdoobahService,
+	traditionService traditions.ITraditionService,
+	maxDepth, maxComplexity int,
+) (*GraphQL, error) {
+	schema, err := newSchema(purdoobahService, traditionService)
+	if err != nil {
+		return &GraphQL{}, err
+	}
+
+	return &GraphQL{
+		MaxDepth:      maxDepth,
+		MaxComplexity: maxComplexity,
+		schema:        schema,
+	}, nil
+}
+
+// Execute runs the request against the schema.
+//
+// Queries that are too deep or too complex, or whose fragments are invalid, are rejected before they're validated or
+// any resolver runs.
+func (g *GraphQL) Execute(ctx context.Context, req *Request) *graphql.Result {
+	doc, err := parser.Parse(parser.ParseParams{
+		Source: source.NewSource(&source.Source{Body: []byte(req.Query), Name: "GraphQL request"}),
+	})
+	if err != nil {
+		return &graphql.Result{Errors: gqlerrors.FormatErrors(err)}
+	}
+
+	err = g.analyze(doc)
+	if err != nil {
+		return rejected(err)
+	}
+
+	return graphql.Do(graphql.Params{
+		Schema:         g.schema,
+		RequestString:  req.Query,
+		VariableValues: req.Variables,
+		OperationName:  req.OperationName,
+		Context:        ctx,
+	})
+}
+
+func rejected(err error) *graphql.Result {
+	return &graphql.Result{Errors: []gqlerrors.FormattedError{gqlerrors.NewFormattedError(err.Error())}}
+}
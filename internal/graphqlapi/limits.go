@@ -0,0 +1,225 @@
+package graphqlapi
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// listMultiplier is the number of items a list field is assumed to return when estimating query complexity.
+const listMultiplier = 10
+
+var (
+	// errInvalidFragments is returned for documents whose fragments can't be analyzed (and which would otherwise be
+	// handed to graphql-go's validator, which recurses forever on fragment cycles).
+	errInvalidFragments = errors.New("invalid fragments")
+
+	// errLimitExceeded is returned as soon as a query is found to be too deep or too complex.
+	errLimitExceeded = errors.New("limit exceeded")
+)
+
+// analyze checks that the document's fragments are well-formed and that none of its operations are deeper or more
+// complex than the limits allow, stopping at the first problem.
+//
+// Every fragment is analyzed once, whether or not it's used, so that the work done is proportional to the size of the
+// document. Introspection fields (e.g. __schema, __type) are skipped so that tooling can always discover the schema.
+func (g *GraphQL) analyze(doc *ast.Document) error {
+	a := &analyzer{
+		schema:        g.schema,
+		maxDepth:      g.MaxDepth,
+		maxComplexity: g.MaxComplexity,
+		fragments:     make(map[string]*ast.FragmentDefinition),
+		costs:         make(map[string]cost),
+		visiting:      make(map[string]bool),
+	}
+
+	for _, definition := range doc.Definitions {
+		if fragment, ok := definition.(*ast.FragmentDefinition); ok && fragment.Name != nil {
+			if _, ok := a.fragments[fragment.Name.Value]; ok {
+				return fmt.Errorf("%w: fragment `%s` is defined more than once", errInvalidFragments, fragment.Name.Value)
+			}
+			a.fragments[fragment.Name.Value] = fragment
+		}
+	}
+
+	for name := range a.fragments {
+		if _, err := a.fragment(name); err != nil {
+			return err
+		}
+	}
+
+	for _, definition := range doc.Definitions {
+		operation, ok := definition.(*ast.OperationDefinition)
+		if !ok {
+			continue
+		}
+
+		var root *graphql.Object
+		if operation.Operation == ast.OperationTypeQuery {
+			root = g.schema.QueryType()
+		}
+
+		if _, err := a.selectionSet(operation.SelectionSet, root); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// cost is how deep a selection set nests (relative to where it's selected) and how complex it is.
+type cost struct {
+	depth      int
+	complexity int
+}
+
+type analyzer struct {
+	schema        graphql.Schema
+	maxDepth      int
+	maxComplexity int
+
+	fragments map[string]*ast.FragmentDefinition
+
+	// costs are the costs of the fragments analyzed so far, by name
+	costs map[string]cost
+
+	// visiting are the fragments being analyzed along the current path, to catch cycles
+	visiting map[string]bool
+}
+
+// fragment returns the cost of the named fragment, analyzing it the first time it's asked for.
+func (a *analyzer) fragment(name string) (cost, error) {
+	if c, ok := a.costs[name]; ok {
+		return c, nil
+	}
+	if a.visiting[name] {
+		return cost{}, fmt.Errorf("%w: fragment `%s` spreads itself", errInvalidFragments, name)
+	}
+	fragment, ok := a.fragments[name]
+	if !ok {
+		return cost{}, fmt.Errorf("%w: unknown fragment `%s`", errInvalidFragments, name)
+	}
+
+	// fragments are analyzed against their type condition, so that their cost is the same wherever they're spread
+	var parent *graphql.Object
+	if fragment.TypeCondition != nil && fragment.TypeCondition.Name != nil {
+		parent, _ = a.schema.Type(fragment.TypeCondition.Name.Value).(*graphql.Object)
+	}
+
+	a.visiting[name] = true
+	c, err := a.selectionSet(fragment.SelectionSet, parent)
+	delete(a.visiting, name)
+	if err != nil {
+		return cost{}, err
+	}
+
+	a.costs[name] = c
+	return c, nil
+}
+
+// selectionSet returns the cost of a selection set on parent, which is nil when the type is unknown.
+func (a *analyzer) selectionSet(set *ast.SelectionSet, parent *graphql.Object) (cost, error) {
+	var total cost
+	if set == nil {
+		return total, nil
+	}
+
+	for _, selection := range set.Selections {
+		var c cost
+		var err error
+
+		switch selection := selection.(type) {
+		case *ast.Field:
+			c, err = a.field(selection, parent)
+		case *ast.InlineFragment:
+			c, err = a.selectionSet(selection.SelectionSet, parent)
+		case *ast.FragmentSpread:
+			if selection.Name == nil {
+				continue
+			}
+			c, err = a.fragment(selection.Name.Value)
+
+			// every spread counts, so that spreading a fragment many times isn't free
+			c.complexity++
+		}
+		if err != nil {
+			return cost{}, err
+		}
+
+		if c.depth > total.depth {
+			total.depth = c.depth
+		}
+		total.complexity += c.complexity
+		if err := a.check(total); err != nil {
+			return cost{}, err
+		}
+	}
+
+	return total, nil
+}
+
+// field returns the cost of a single field, including everything selected beneath it.
+func (a *analyzer) field(field *ast.Field, parent *graphql.Object) (cost, error) {
+	if field.Name == nil || strings.HasPrefix(field.Name.Value, "__") {
+		return cost{}, nil
+	}
+
+	// scalars don't add another level of nesting
+	if field.SelectionSet == nil {
+		return cost{complexity: 1}, nil
+	}
+
+	var child *graphql.Object
+	isList := false
+	if parent != nil {
+		if definition, ok := parent.Fields()[field.Name.Value]; ok {
+			child, isList = unwrap(definition.Type)
+		}
+	}
+
+	c, err := a.selectionSet(field.SelectionSet, child)
+	if err != nil {
+		return cost{}, err
+	}
+	if isList {
+		c.complexity *= listMultiplier
+	}
+	c.depth++
+	c.complexity++
+
+	return c, a.check(c)
+}
+
+// check stops the analysis as soon as a cost is over the limits. Costs only grow as they're added up, so a part of a
+// query that's over the limits means that the whole query is.
+func (a *analyzer) check(c cost) error {
+	if c.depth > a.maxDepth {
+		return fmt.Errorf("%w: query depth exceeds the maximum of %d", errLimitExceeded, a.maxDepth)
+	}
+	if c.complexity > a.maxComplexity {
+		return fmt.Errorf("%w: query complexity exceeds the maximum of %d", errLimitExceeded, a.maxComplexity)
+	}
+	return nil
+}
+
+// unwrap strips the non-null and list wrappers off of a field's type, returning the underlying object type (or nil
+// if it's a scalar) and whether a list was unwrapped along the way.
+func unwrap(t graphql.Output) (*graphql.Object, bool) {
+	isList := false
+	for {
+		switch wrapped := t.(type) {
+		case *graphql.NonNull:
+			t = wrapped.OfType
+		case *graphql.List:
+			isList = true
+			t = wrapped.OfType
+		case *graphql.Object:
+			return wrapped, isList
+		default:
+			return nil, isList
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package graphqlapi
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/purdoobahs/purdoobahs.com/internal/inmemorydatabase"
+	"github.com/purdoobahs/purdoobahs.com/internal/purdoobahs"
+	"github.com/purdoobahs/purdoobahs.com/internal/traditions"
+)
+
+func newTestGraphQL(t *testing.T) *GraphQL {
+	t.Helper()
+
+	g, err := NewGraphQL(
+		inmemorydatabase.NewPurdoobahService(map[string]*purdoobahs.Purdoobah{}),
+		inmemorydatabase.NewTraditionService(map[string]*traditions.Tradition{}),
+		6, 2000,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return g
+}
+
+func TestExecuteRejectsInvalidFragments(t *testing.T) {
+	g := newTestGraphQL(t)
+
+	queries := map[string]string{
+		"self-spreading fragment":      `{...A} fragment A on Query{...A}`,
+		"fragment cycle":               `{...A} fragment A on Query{...B} fragment B on Query{...A}`,
+		"unused fragment cycle":        `{traditions{id}} fragment A on Query{...A}`,
+		"unknown fragment":             `{...A}`,
+		"fragment defined twice":       `{...A} fragment A on Query{traditions{id}} fragment A on Query{traditions{name}}`,
+		"cycle through a nested field": `{...A} fragment A on Query{traditions{...B}} fragment B on Tradition{...B}`,
+	}
+
+	for name, query := range queries {
+		t.Run(name, func(t *testing.T) {
+			result := g.Execute(context.Background(), &Request{Query: query})
+			if len(result.Errors) == 0 {
+				t.Fatalf("expected the query to be rejected: %s", query)
+			}
+			if !strings.Contains(result.Errors[0].Message, errInvalidFragments.Error()) {
+				t.Fatalf("expected an invalid fragments error, got: %s", result.Errors[0].Message)
+			}
+		})
+	}
+}
+
+func TestExecuteRejectsExponentialFragments(t *testing.T) {
+	g := newTestGraphQL(t)
+
+	// every fragment spreads the next one twice, so walking every spread would visit 2^26 fragments
+	var b strings.Builder
+	b.WriteString("{...F0}")
+	const levels = 26
+	for i := 0; i < levels; i++ {
+		fmt.Fprintf(&b, " fragment F%d on Query{...F%d ...F%d}", i, i+1, i+1)
+	}
+	fmt.Fprintf(&b, " fragment F%d on Query{traditions{id}}", levels)
+
+	start := time.Now()
+	result := g.Execute(context.Background(), &Request{Query: b.String()})
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("analysis took %s", elapsed)
+	}
+	if len(result.Errors) == 0 || !strings.Contains(result.Errors[0].Message, errLimitExceeded.Error()) {
+		t.Fatalf("expected the query to exceed the complexity limit, got: %v", result.Errors)
+	}
+}
+
+func TestExecuteAllowsFragments(t *testing.T) {
+	g := newTestGraphQL(t)
+
+	query := `{...A ...A} fragment A on Query{traditions{...B}} fragment B on Tradition{id name}`
+	result := g.Execute(context.Background(), &Request{Query: query})
+	if len(result.Errors) != 0 {
+		t.Fatalf("expected the query to run, got: %v", result.Errors)
+	}
+}
@@ -0,0 +1,500 @@
+package graphqlapi
+
+import (
+	"errors"
+
+	"github.com/purdoobahs/purdoobahs.com/internal/purdoobahs"
+	"github.com/purdoobahs/purdoobahs.com/internal/traditions"
+
+	"github.com/graphql-go/graphql"
+)
+
+// section is the GraphQL source of a single section year.
+type section struct {
+	Year    int
+	Members []*purdoobahs.Purdoobah
+}
+
+// newSchema builds the GraphQL schema, resolving everything through the Purdoobah and Tradition services.
+func newSchema(purdoobahService purdoobahs.IPurdoobahService, traditionService traditions.ITraditionService) (graphql.Schema, error) {
+	// sectionByYear resolves a single section, or nil if nobody marched that year
+	sectionByYear := func(year int) (*section, error) {
+		members, err := purdoobahService.SectionByYear(year)
+		if errors.Is(err, purdoobahs.ErrSectionNotFound) {
+			return nil, nil
+		} else if err != nil {
+			return nil, err
+		}
+		return &section{Year: year, Members: members}, nil
+	}
+
+	birthCertificateNameType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "BirthCertificateName",
+		Description: "A Purdoobah's real name",
+		Fields: graphql.Fields{
+			"first":  &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"middle": &graphql.Field{Type: graphql.String, Resolve: emptyAsNull},
+			"last":   &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+		},
+	})
+
+	educationType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Education",
+		Description: "A Purdoobah's educational career at Purdue",
+		Fields: graphql.Fields{
+			"major": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"minor": &graphql.Field{Type: graphql.String, Resolve: emptyAsNull},
+			"year": &graphql.Field{
+				Type:        graphql.String,
+				Description: "Stored year in school (freshman, sophomore, junior, senior, super-senior, or alumni)",
+				Resolve:     emptyAsNull,
+			},
+			"effectiveYear": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.String),
+				Description: "Year in school derived from the years marched, honoring the stored year as an override",
+				Resolve:     jsonField("effective_year"),
+			},
+		},
+	})
+
+	seasonType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Season",
+		Description: "What was true of a Purdoobah during a single season",
+		Fields: graphql.Fields{
+			"year": &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"classStanding": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.String),
+				Description: "Year in school that season (freshman, sophomore, junior, senior, or super-senior)",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return string(p.Source.(*purdoobahs.Season).ClassStanding), nil
+				},
+			},
+			"major": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"minor": &graphql.Field{Type: graphql.String, Resolve: emptyAsNull},
+			"role":  &graphql.Field{Type: graphql.String, Resolve: emptyAsNull},
+		},
+	})
+
+	hometownType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Hometown",
+		Description: "Where a Purdoobah grew up",
+		Fields: graphql.Fields{
+			"city":  &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"state": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+		},
+	})
+
+	locationType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Location",
+		Description: "Where an alumnus currently lives",
+		Fields: graphql.Fields{
+			"city":  &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"state": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+		},
+	})
+
+	positionType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Position",
+		Description: "A single job in an alumnus' career",
+		Fields: graphql.Fields{
+			"employer": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"title":    &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"industry": &graphql.Field{Type: graphql.String, Resolve: emptyAsNull},
+			"start":    &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"end": &graphql.Field{
+				Type:        graphql.Int,
+				Description: "null for a current position",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					if end := p.Source.(purdoobahs.Position).End; end != 0 {
+						return end, nil
+					}
+					return nil, nil
+				},
+			},
+		},
+	})
+
+	alumniType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Alumni",
+		Description: "A Purdoobah's post-grad life; only what they've agreed to share",
+		Fields: graphql.Fields{
+			"employer": &graphql.Field{
+				Type: graphql.String,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return nullIfEmpty(p.Source.(*purdoobahs.Purdoobah).Alumni.Employer), nil
+				},
+			},
+			"title": &graphql.Field{
+				Type: graphql.String,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return nullIfEmpty(p.Source.(*purdoobahs.Purdoobah).Alumni.Title), nil
+				},
+			},
+			"industry": &graphql.Field{
+				Type: graphql.String,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return nullIfEmpty(p.Source.(*purdoobahs.Purdoobah).Alumni.Industry), nil
+				},
+			},
+			"location": &graphql.Field{
+				Type: locationType,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					if location := p.Source.(*purdoobahs.Purdoobah).Alumni.Location; location.City != "" {
+						return location, nil
+					}
+					return nil, nil
+				},
+			},
+			"graduationYear": &graphql.Field{
+				Type:        graphql.Int,
+				Description: "Estimated from the years marched unless it was recorded; null if the marching history is unknown",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					if year, _ := p.Source.(*purdoobahs.Purdoobah).GraduationYear(); year != 0 {
+						return year, nil
+					}
+					return nil, nil
+				},
+			},
+			"positions": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(positionType))),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*purdoobahs.Purdoobah).Alumni.Positions, nil
+				},
+			},
+		},
+	})
+
+	socialsType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Socials",
+		Description: "Links to a Purdoobah's social media accounts",
+		Fields: graphql.Fields{
+			"facebook":  &graphql.Field{Type: graphql.String, Resolve: emptyAsNull},
+			"instagram": &graphql.Field{Type: graphql.String, Resolve: emptyAsNull},
+			"linkedin":  &graphql.Field{Type: graphql.String, Resolve: emptyAsNull},
+		},
+	})
+
+	leadershipType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Leadership",
+		Description: "A role a Purdoobah held within the section, and the years they held it",
+		Fields: graphql.Fields{
+			"role":      &graphql.Field{Type: graphql.NewNonNull(graphql.String), Resolve: jsonField("role")},
+			"committee": &graphql.Field{Type: graphql.String, Resolve: emptyAsNull},
+			"years":     &graphql.Field{Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(graphql.Int)))},
+			"title": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.String),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(purdoobahs.Leadership).Title(), nil
+				},
+			},
+		},
+	})
+
+	achievementsType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Achievements",
+		Description: "The achievements a Purdoobah garnered during their tenure",
+		Fields: graphql.Fields{
+			"leadership": &graphql.Field{Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(leadershipType)))},
+			"studentLeader": &graphql.Field{
+				Type:              graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(graphql.Int))),
+				DeprecationReason: "Use `leadership`, which distinguishes between leadership roles.",
+				Resolve:           jsonField("student_leader"),
+			},
+			"bottomFeederCommittee": &graphql.Field{Type: graphql.NewNonNull(graphql.Boolean)},
+			"spoonsassinsVictories": &graphql.Field{Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(graphql.Int)))},
+			"kappaKappaPsi":         &graphql.Field{Type: graphql.NewNonNull(graphql.Boolean)},
+			"tauBetaSigma":          &graphql.Field{Type: graphql.NewNonNull(graphql.Boolean)},
+		},
+	})
+
+	imageType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Image",
+		Description: "A cache-busted image",
+		Fields: graphql.Fields{
+			"file":   &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"alt":    &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"width":  &graphql.Field{Type: graphql.NewNonNull(graphql.Int), Description: "Width in pixels"},
+			"height": &graphql.Field{Type: graphql.NewNonNull(graphql.Int), Description: "Height in pixels"},
+			"dominantColor": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.String),
+				Description: "Most common color, as a hex code",
+			},
+			"placeholder": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.String),
+				Description: "Tiny PNG version as a data URL, to show blurred while the image loads",
+			},
+		},
+	})
+
+	purdoobahType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Purdoobah",
+		Description: "A member of the Purdue All-American Marching Band Toobah section",
+		Fields: graphql.Fields{
+			"id":                   &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+			"name":                 &graphql.Field{Type: graphql.NewNonNull(graphql.String), Description: "Nickname"},
+			"birthCertificateName": &graphql.Field{Type: graphql.NewNonNull(birthCertificateNameType)},
+			"emoji":                &graphql.Field{Type: graphql.String, Resolve: emptyAsNull},
+			"yearsMarched": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(graphql.Int))),
+				Description: "Every year this Purdoobah marched (-1 if unknown)",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*purdoobahs.Purdoobah).Marching.YearsMarched, nil
+				},
+			},
+			"shoutout": &graphql.Field{
+				Type:        graphql.String,
+				Description: "A link to this Purdoobah's Instagram shoutout post",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return nullIfEmpty(p.Source.(*purdoobahs.Purdoobah).Marching.Shoutout), nil
+				},
+			},
+			"seasons": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(seasonType))),
+				Description: "What was true of this Purdoobah during each season they marched",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					purdoobah := p.Source.(*purdoobahs.Purdoobah)
+					seasons := make([]*purdoobahs.Season, 0, len(purdoobah.Marching.YearsMarched))
+					for _, year := range purdoobah.Marching.YearsMarched {
+						if season := purdoobah.AsOfSeason(year).Season; season != nil {
+							seasons = append(seasons, season)
+						}
+					}
+					return seasons, nil
+				},
+			},
+			"season": &graphql.Field{
+				Type:        seasonType,
+				Description: "The season this Purdoobah is described as of, when retrieved as a member of a section",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					if season := p.Source.(*purdoobahs.Purdoobah).Season; season != nil {
+						return season, nil
+					}
+					return nil, nil
+				},
+			},
+			"education": &graphql.Field{Type: graphql.NewNonNull(educationType)},
+			"hometown":  &graphql.Field{Type: graphql.NewNonNull(hometownType)},
+			"job": &graphql.Field{
+				Type: graphql.String,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return nullIfEmpty(p.Source.(*purdoobahs.Purdoobah).JobTitle()), nil
+				},
+			},
+			"alumni": &graphql.Field{
+				Type: graphql.NewNonNull(alumniType),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source, nil
+				},
+			},
+			"hobbies": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(graphql.String))),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*purdoobahs.Purdoobah).Personal.Hobbies, nil
+				},
+			},
+			"socials": &graphql.Field{
+				Type: graphql.NewNonNull(socialsType),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*purdoobahs.Purdoobah).Personal.Socials, nil
+				},
+			},
+			"achievements": &graphql.Field{Type: graphql.NewNonNull(achievementsType)},
+			"image": &graphql.Field{
+				Type: graphql.NewNonNull(imageType),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*purdoobahs.Purdoobah).Metadata.Image, nil
+				},
+			},
+		},
+	})
+
+	sectionType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Section",
+		Description: "Every Purdoobah that marched during a single year",
+		Fields: graphql.Fields{
+			"year": &graphql.Field{Type: graphql.NewNonNull(graphql.Int), Description: "-1 if unknown"},
+			"size": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.Int),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return len(p.Source.(*section).Members), nil
+				},
+			},
+			"members": &graphql.Field{Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(purdoobahType)))},
+			"studentLeaders": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(purdoobahType))),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					s := p.Source.(*section)
+					studentLeaders := make([]*purdoobahs.Purdoobah, 0)
+					for _, member := range s.Members {
+						if member.IsStudentLeaderInYear(s.Year) {
+							studentLeaders = append(studentLeaders, member)
+						}
+					}
+					return studentLeaders, nil
+				},
+			},
+		},
+	})
+
+	// a Purdoobah's sections reference back to the Section type
+	purdoobahType.AddFieldConfig("sections", &graphql.Field{
+		Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(sectionType))),
+		Description: "Every section this Purdoobah marched in",
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			sections := make([]*section, 0)
+			for _, year := range p.Source.(*purdoobahs.Purdoobah).Marching.YearsMarched {
+				s, err := sectionByYear(year)
+				if err != nil {
+					return nil, err
+				}
+				if s != nil {
+					sections = append(sections, s)
+				}
+			}
+			return sections, nil
+		},
+	})
+
+	currentSectionType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "CurrentSection",
+		Description: "The section marching this academic year, bucketed by leadership and year in school",
+		Fields: graphql.Fields{
+			"studentLeaders": &graphql.Field{Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(purdoobahType)))},
+			"superSeniors":   &graphql.Field{Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(purdoobahType)))},
+			"seniors":        &graphql.Field{Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(purdoobahType)))},
+			"juniors":        &graphql.Field{Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(purdoobahType)))},
+			"sophomores":     &graphql.Field{Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(purdoobahType)))},
+			"freshmen":       &graphql.Field{Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(purdoobahType)))},
+		},
+	})
+
+	traditionType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Tradition",
+		Description: "A tradition of the section",
+		Fields: graphql.Fields{
+			"id":          &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+			"name":        &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"description": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"image": &graphql.Field{
+				Type: graphql.NewNonNull(imageType),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*traditions.Tradition).Metadata.Image, nil
+				},
+			},
+		},
+	})
+
+	queryType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"purdoobahs": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(purdoobahType))),
+				Description: "Every Purdoobah, sorted by name",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return purdoobahService.All()
+				},
+			},
+			"purdoobah": &graphql.Field{
+				Type:        purdoobahType,
+				Description: "A single Purdoobah by ID, or null if they don't exist",
+				Args: graphql.FieldConfigArgument{
+					"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					purdoobah, err := purdoobahService.ByName(p.Args["id"].(string))
+					if errors.Is(err, purdoobahs.ErrPurdoobahNotFound) {
+						return nil, nil
+					}
+					return purdoobah, err
+				},
+			},
+			"sectionYears": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(graphql.Int))),
+				Description: "Every year at least one Purdoobah marched",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return purdoobahService.AllSectionYears()
+				},
+			},
+			"section": &graphql.Field{
+				Type:        sectionType,
+				Description: "The section of a single year, or null if nobody marched that year",
+				Args: graphql.FieldConfigArgument{
+					"year": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.Int)},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					s, err := sectionByYear(p.Args["year"].(int))
+					if s == nil {
+						return nil, err
+					}
+					return s, err
+				},
+			},
+			"currentSection": &graphql.Field{
+				Type:        graphql.NewNonNull(currentSectionType),
+				Description: "The section marching this academic year",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return purdoobahService.CurrentSection()
+				},
+			},
+			"traditions": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(traditionType))),
+				Description: "Every tradition, sorted by name",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return traditionService.All()
+				},
+			},
+			"tradition": &graphql.Field{
+				Type:        traditionType,
+				Description: "A single tradition by ID, or null if it doesn't exist",
+				Args: graphql.FieldConfigArgument{
+					"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					tradition, err := traditionService.ByName(p.Args["id"].(string))
+					if errors.Is(err, traditions.ErrTraditionNotFound) {
+						return nil, nil
+					}
+					return tradition, err
+				},
+			},
+		},
+	})
+
+	return graphql.NewSchema(graphql.SchemaConfig{
+		Query: queryType,
+	})
+}
+
+// emptyAsNull resolves a field with the default resolver, but turns empty strings into null.
+func emptyAsNull(p graphql.ResolveParams) (interface{}, error) {
+	v, err := graphql.DefaultResolveFn(p)
+	if year, ok := v.(purdoobahs.Year); ok {
+		v = string(year)
+	}
+	if s, ok := v.(string); ok {
+		return nullIfEmpty(s), err
+	}
+	return v, err
+}
+
+// jsonField resolves the source's struct field with the given JSON name, for fields whose GraphQL name differs.
+func jsonField(name string) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		p.Info.FieldName = name
+		v, err := graphql.DefaultResolveFn(p)
+		if year, ok := v.(purdoobahs.Year); ok {
+			return string(year), err
+		}
+		if role, ok := v.(purdoobahs.LeadershipRole); ok {
+			return string(role), err
+		}
+		return v, err
+	}
+}
+
+func nullIfEmpty(s string) interface{} {
+	if s == "" {
+		return nil
+	}
+	return s
+}
@@ -0,0 +1,17 @@
+package httpheader
+
+type RateLimits HttpHeader
+
+func (rl RateLimits) String() string {
+	return string(rl)
+}
+
+// List of experimental rate limits HTTP headers.
+//
+// Sourced from: https://datatracker.ietf.org/doc/draft-ietf-httpapi-ratelimit-headers/
+const (
+	ExperimentalRateLimitLimit     RateLimits = "RateLimit-Limit"
+	ExperimentalRateLimitPolicy    RateLimits = "RateLimit-Policy"
+	ExperimentalRateLimitRemaining RateLimits = "RateLimit-Remaining"
+	ExperimentalRateLimitReset     RateLimits = "RateLimit-Reset"
+)
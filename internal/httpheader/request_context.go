@@ -14,3 +14,8 @@ const (
 	ReferrerPolicy RequestContext = "Referrer-Policy"
 	UserAgent      RequestContext = "User-Agent"
 )
+
+// List of non-standard request context HTTP headers.
+const (
+	NonstandardXRequestId RequestContext = "X-Request-ID"
+)
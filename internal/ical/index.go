@@ -0,0 +1,340 @@
+package ical
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// maxLineLength is the longest a content line may be, in octets, before it must be folded (RFC 5545 3.1).
+const maxLineLength = 75
+
+const (
+	dateLayout      = "20060102"
+	localTimeLayout = "20060102T150405"
+	utcTimeLayout   = "20060102T150405Z"
+)
+
+// Calendar is an iCalendar (RFC 5545) calendar that can be subscribed to.
+type Calendar struct {
+	// ProductID identifies who made the calendar (e.g. "-//Purdoobahs//purdoobahs.com//EN").
+	ProductID string
+
+	Name        string
+	Description string
+
+	// RefreshInterval is how often subscribers should check for changes (RFC 7986 5.7).
+	RefreshInterval time.Duration
+
+	Events []*Event
+}
+
+// Event is a single VEVENT.
+type Event struct {
+	// UID has to be globally unique and stay the same for as long as the event exists.
+	UID string
+
+	// Stamp is when the event's information was last revised.
+	Stamp time.Time
+
+	Summary     string
+	Description string
+	Location    string
+	URL         string
+	Categories  []string
+
+	// Start and End are written in their time zone, which is described by a VTIMEZONE. All-day events only use their
+	// dates, and End is exclusive. End may be zero.
+	Start  time.Time
+	End    time.Time
+	AllDay bool
+}
+
+// Encode writes the calendar, along with a VTIMEZONE for every time zone its events use.
+func (c *Calendar) Encode(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+
+	lines := []string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		fmt.Sprintf("PRODID:%s", c.ProductID),
+		"CALSCALE:GREGORIAN",
+		"METHOD:PUBLISH",
+	}
+	if c.Name != "" {
+		lines = append(lines,
+			fmt.Sprintf("NAME:%s", escapeText(c.Name)),
+			fmt.Sprintf("X-WR-CALNAME:%s", escapeText(c.Name)),
+		)
+	}
+	if c.Description != "" {
+		lines = append(lines,
+			fmt.Sprintf("DESCRIPTION:%s", escapeText(c.Description)),
+			fmt.Sprintf("X-WR-CALDESC:%s", escapeText(c.Description)),
+		)
+	}
+	if c.RefreshInterval > 0 {
+		lines = append(lines,
+			fmt.Sprintf("REFRESH-INTERVAL;VALUE=DURATION:%s", duration(c.RefreshInterval)),
+			fmt.Sprintf("X-PUBLISHED-TTL:%s", duration(c.RefreshInterval)),
+		)
+	}
+
+	lines = append(lines, c.timezones()...)
+	for _, e := range c.Events {
+		lines = append(lines, e.lines()...)
+	}
+	lines = append(lines, "END:VCALENDAR")
+
+	for _, line := range lines {
+		_, err := bw.WriteString(fold(line))
+		if err != nil {
+			return err
+		}
+	}
+
+	return bw.Flush()
+}
+
+func (e *Event) lines() []string {
+	lines := []string{
+		"BEGIN:VEVENT",
+		fmt.Sprintf("UID:%s", e.UID),
+		fmt.Sprintf("DTSTAMP:%s", e.Stamp.UTC().Format(utcTimeLayout)),
+		fmt.Sprintf("DTSTART%s", dateTime(e.Start, e.AllDay)),
+	}
+	if !e.End.IsZero() {
+		lines = append(lines, fmt.Sprintf("DTEND%s", dateTime(e.End, e.AllDay)))
+	}
+	lines = append(lines, fmt.Sprintf("SUMMARY:%s", escapeText(e.Summary)))
+	if e.Description != "" {
+		lines = append(lines, fmt.Sprintf("DESCRIPTION:%s", escapeText(e.Description)))
+	}
+	if e.Location != "" {
+		lines = append(lines, fmt.Sprintf("LOCATION:%s", escapeText(e.Location)))
+	}
+	if e.URL != "" {
+		lines = append(lines, fmt.Sprintf("URL:%s", e.URL))
+	}
+	if len(e.Categories) > 0 {
+		categories := make([]string, 0, len(e.Categories))
+		for _, category := range e.Categories {
+			categories = append(categories, escapeText(category))
+		}
+		lines = append(lines, fmt.Sprintf("CATEGORIES:%s", strings.Join(categories, ",")))
+	}
+	lines = append(lines, "TRANSP:TRANSPARENT", "END:VEVENT")
+
+	return lines
+}
+
+// dateTime formats a DTSTART or DTEND value, including its parameters.
+func dateTime(t time.Time, allDay bool) string {
+	switch {
+	case allDay:
+		return fmt.Sprintf(";VALUE=DATE:%s", t.Format(dateLayout))
+	case t.Location() == time.UTC:
+		return fmt.Sprintf(":%s", t.Format(utcTimeLayout))
+	default:
+		return fmt.Sprintf(";TZID=%s:%s", t.Location().String(), t.Format(localTimeLayout))
+	}
+}
+
+// timezones describes every time zone the timed events use, from a year before the first event until a year after
+// the last one.
+func (c *Calendar) timezones() []string {
+	type span struct {
+		loc      *time.Location
+		from, to time.Time
+	}
+
+	spans := make(map[string]*span)
+	for _, e := range c.Events {
+		if e.AllDay || e.Start.Location() == time.UTC {
+			continue
+		}
+
+		for _, t := range []time.Time{e.Start, e.End} {
+			if t.IsZero() {
+				continue
+			}
+
+			name := t.Location().String()
+			s, ok := spans[name]
+			if !ok {
+				spans[name] = &span{loc: t.Location(), from: t, to: t}
+				continue
+			}
+			if t.Before(s.from) {
+				s.from = t
+			}
+			if t.After(s.to) {
+				s.to = t
+			}
+		}
+	}
+
+	names := make([]string, 0, len(spans))
+	for name := range spans {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	lines := make([]string, 0)
+	for _, name := range names {
+		s := spans[name]
+		lines = append(lines, timezone(s.loc, s.from.AddDate(-1, 0, 0), s.to.AddDate(1, 0, 0))...)
+	}
+	return lines
+}
+
+// timezone writes a VTIMEZONE with every UTC offset transition of the location between from and to, as found in the
+// system's zoneinfo. Every transition is listed on its own rather than as a recurrence rule, so that changes to a time
+// zone's rules are described exactly.
+func timezone(loc *time.Location, from, to time.Time) []string {
+	lines := []string{
+		"BEGIN:VTIMEZONE",
+		fmt.Sprintf("TZID:%s", loc.String()),
+	}
+
+	// the observance in effect at the start of the span
+	from = from.In(loc)
+	name, offset := from.Zone()
+	lines = append(lines, observance(from.IsDST(), from, offset, offset, name)...)
+
+	// then every transition after it
+	for _, t := range transitions(loc, from, to) {
+		_, before := t.Add(-time.Second).Zone()
+		name, after := t.Zone()
+		lines = append(lines, observance(t.IsDST(), t.In(time.FixedZone("", before)), before, after, name)...)
+	}
+
+	return append(lines, "END:VTIMEZONE")
+}
+
+// observance writes a STANDARD or DAYLIGHT component. Its onset is written as local time in the offset before it.
+func observance(daylight bool, onset time.Time, offsetFrom, offsetTo int, name string) []string {
+	kind := "STANDARD"
+	if daylight {
+		kind = "DAYLIGHT"
+	}
+
+	return []string{
+		fmt.Sprintf("BEGIN:%s", kind),
+		fmt.Sprintf("DTSTART:%s", onset.Format(localTimeLayout)),
+		fmt.Sprintf("TZOFFSETFROM:%s", utcOffset(offsetFrom)),
+		fmt.Sprintf("TZOFFSETTO:%s", utcOffset(offsetTo)),
+		fmt.Sprintf("TZNAME:%s", escapeText(name)),
+		fmt.Sprintf("END:%s", kind),
+	}
+}
+
+// transitions finds the instants the location's UTC offset changes between from and to. Offsets are checked daily,
+// and every change is then narrowed down to the second.
+func transitions(loc *time.Location, from, to time.Time) []time.Time {
+	found := make([]time.Time, 0)
+
+	for day := from; day.Before(to); day = day.Add(24 * time.Hour) {
+		next := day.Add(24 * time.Hour)
+		_, before := day.In(loc).Zone()
+		_, after := next.In(loc).Zone()
+		if before == after {
+			continue
+		}
+
+		// the offset changes somewhere after lo and no later than hi
+		lo, hi := day, next
+		for hi.Sub(lo) > time.Second {
+			mid := lo.Add(hi.Sub(lo) / 2).Truncate(time.Second)
+			if _, offset := mid.In(loc).Zone(); offset == before {
+				lo = mid
+			} else {
+				hi = mid
+			}
+		}
+		found = append(found, hi.In(loc))
+	}
+
+	return found
+}
+
+// utcOffset formats an offset in seconds east of UTC (e.g. "-0500").
+func utcOffset(seconds int) string {
+	sign := "+"
+	if seconds < 0 {
+		sign = "-"
+		seconds = -seconds
+	}
+
+	offset := fmt.Sprintf("%s%02d%02d", sign, seconds/3600, seconds%3600/60)
+	if seconds%60 != 0 {
+		offset += fmt.Sprintf("%02d", seconds%60)
+	}
+	return offset
+}
+
+// duration formats a DURATION value (e.g. "PT12H").
+func duration(d time.Duration) string {
+	d = d.Round(time.Second)
+
+	var sb strings.Builder
+	sb.WriteString("P")
+	if days := d / (24 * time.Hour); days > 0 {
+		fmt.Fprintf(&sb, "%dD", days)
+		d -= days * 24 * time.Hour
+	}
+	if d > 0 {
+		sb.WriteString("T")
+		if hours := d / time.Hour; hours > 0 {
+			fmt.Fprintf(&sb, "%dH", hours)
+			d -= hours * time.Hour
+		}
+		if minutes := d / time.Minute; minutes > 0 {
+			fmt.Fprintf(&sb, "%dM", minutes)
+			d -= minutes * time.Minute
+		}
+		if seconds := d / time.Second; seconds > 0 {
+			fmt.Fprintf(&sb, "%dS", seconds)
+		}
+	}
+	if sb.Len() == 1 {
+		sb.WriteString("T0S")
+	}
+	return sb.String()
+}
+
+// escapeText escapes a TEXT property value (RFC 5545 3.3.11).
+func escapeText(s string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		",", `\,`,
+		";", `\;`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+	).Replace(s)
+}
+
+// fold terminates a content line with CRLF, splitting it into multiple physical lines if it's too long. Lines are
+// never split in the middle of a multi-octet UTF-8 character.
+func fold(line string) string {
+	var sb strings.Builder
+
+	length := 0
+	for _, r := range line {
+		size := utf8.RuneLen(r)
+		if length+size > maxLineLength {
+			sb.WriteString("\r\n ")
+			// the leading space of a continuation line counts towards its length
+			length = 1
+		}
+		sb.WriteRune(r)
+		length += size
+	}
+	sb.WriteString("\r\n")
+
+	return sb.String()
+}
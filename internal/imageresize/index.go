@@ -0,0 +1,235 @@
+package imageresize
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/purdoobahs/purdoobahs.com/internal/imaging"
+	"github.com/purdoobahs/purdoobahs.com/internal/keylock"
+)
+
+var (
+	// ErrUnsupportedWidth is returned when a width isn't one of the Resizer's widths.
+	ErrUnsupportedWidth = errors.New("the width isn't supported")
+
+	// ErrUnsupportedFormat is returned when an image can't be served in the requested format.
+	ErrUnsupportedFormat = errors.New("the format isn't supported")
+)
+
+// Format is the format a resized image is served in.
+type Format string
+
+const (
+	JPEG Format = "jpeg"
+	PNG  Format = "png"
+
+	// WebP images can't be encoded, only passed through as they are.
+	WebP Format = "webp"
+)
+
+// Resizer makes smaller variants of the static images, and keeps them in a cache on disk so that every variant is only
+// made once.
+//
+// Variants are keyed by the hash of the image's contents (as handed out by the CacheBuster), so a cached variant never
+// goes stale: a changed image gets a new hash, and so new variants.
+type Resizer struct {
+	// Widths are the only widths variants are made in, so that the cache can't be filled up with every width there is.
+	Widths []int
+
+	// JPEGQuality is the quality (1-100) JPEG variants are encoded with.
+	JPEGQuality int
+
+	cacheDir string
+
+	mu sync.Mutex
+
+	// sizes are the dimensions of every image that's been looked at, by path
+	sizes map[string]image.Point
+
+	// locks keep the same variant from being made more than once at a time, by cache key
+	locks *keylock.KeyLock
+}
+
+func NewResizer(cacheDir string) (*Resizer, error) {
+	err := os.MkdirAll(cacheDir, 0755)
+	if err != nil {
+		return &Resizer{}, err
+	}
+
+	return &Resizer{
+		Widths:      []int{160, 320, 480, 640, 960, 1280, 1920},
+		JPEGQuality: 80,
+		cacheDir:    cacheDir,
+		sizes:       make(map[string]image.Point),
+		locks:       keylock.NewKeyLock(),
+	}, nil
+}
+
+// IsAllowedWidth returns whether variants are made in the given width.
+func (rs *Resizer) IsAllowedWidth(width int) bool {
+	for _, w := range rs.Widths {
+		if w == width {
+			return true
+		}
+	}
+	return false
+}
+
+// Size returns the width and height of the image at the given path. Sizes are remembered, as images never change once
+// they're hashed.
+func (rs *Resizer) Size(path string) (int, int, error) {
+	rs.mu.Lock()
+	size, ok := rs.sizes[path]
+	rs.mu.Unlock()
+	if ok {
+		return size.X, size.Y, nil
+	}
+
+	width, height, err := imaging.Size(path)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	rs.mu.Lock()
+	rs.sizes[path] = image.Point{X: width, Y: height}
+	rs.mu.Unlock()
+	return width, height, nil
+}
+
+// DefaultFormat is the format variants of the image at the given path are served in, unless another is requested:
+// PNG for PNGs (which may be transparent), and JPEG for everything else.
+func DefaultFormat(path string) Format {
+	if strings.EqualFold(filepath.Ext(path), ".png") {
+		return PNG
+	}
+	return JPEG
+}
+
+// Variant returns the path of the image at the given path, scaled down to the given width and encoded in the given
+// format. The image itself is passed through when it's already in that format and no wider than that, as well as for
+// WebP, which can't be encoded.
+func (rs *Resizer) Variant(path, hash string, width int, format Format) (string, error) {
+	if !rs.IsAllowedWidth(width) {
+		return "", fmt.Errorf("%w: `%d`", ErrUnsupportedWidth, width)
+	}
+
+	sourceFormat := formatOf(path)
+	switch format {
+	case JPEG, PNG:
+	case WebP:
+		if sourceFormat != WebP {
+			return "", fmt.Errorf("%w: only WebP images can be served as WebP", ErrUnsupportedFormat)
+		}
+		return path, nil
+	default:
+		return "", fmt.Errorf("%w: `%s`", ErrUnsupportedFormat, format)
+	}
+
+	sourceWidth, _, err := rs.Size(path)
+	if err != nil {
+		return "", err
+	}
+	if format == sourceFormat && width >= sourceWidth {
+		return path, nil
+	}
+
+	// check the cache, making the variant if it isn't there yet
+	key := fmt.Sprintf("%s-%d.%s", hash, width, format)
+	variantPath := filepath.Join(rs.cacheDir, key)
+
+	rs.locks.Lock(key)
+	defer rs.locks.Unlock(key)
+
+	if _, err := os.Stat(variantPath); err == nil {
+		return variantPath, nil
+	}
+
+	err = rs.generate(path, variantPath, width, format)
+	if err != nil {
+		return "", err
+	}
+	return variantPath, nil
+}
+
+// generate makes a variant, writing it to a temporary file first so that a half-written variant is never served.
+func (rs *Resizer) generate(path, variantPath string, width int, format Format) error {
+	img, err := imaging.Decode(path)
+	if err != nil {
+		return err
+	}
+	// only the width matters, so the height is left unbounded
+	img = imaging.Fit(img, width, img.Bounds().Dy())
+
+	f, err := os.CreateTemp(rs.cacheDir, ".variant-*")
+	if err != nil {
+		return err
+	}
+	tempPath := f.Name()
+	f.Close()
+	defer os.Remove(tempPath)
+
+	switch format {
+	case PNG:
+		err = imaging.WritePNG(tempPath, img)
+	default:
+		err = imaging.WriteJPEG(tempPath, img, rs.JPEGQuality)
+	}
+	if err != nil {
+		return err
+	}
+
+	// temporary files are only readable by their owner
+	err = os.Chmod(tempPath, 0644)
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(tempPath, variantPath)
+}
+
+// Prune deletes every cached variant of an image whose hash isn't current anymore (i.e. of an image that's since
+// changed or been removed), along with any temporary files left behind, returning how many files were deleted. It
+// should be called before any variants are asked for, so that no variant is deleted while it's being made.
+func (rs *Resizer) Prune(isCurrent func(hash string) bool) (int, error) {
+	entries, err := os.ReadDir(rs.cacheDir)
+	if err != nil {
+		return 0, err
+	}
+
+	pruned := 0
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		// variants are named "<hash>-<width>.<format>"
+		name := entry.Name()
+		hash, _, isVariant := strings.Cut(name, "-")
+		if isVariant && !strings.HasPrefix(name, ".") && isCurrent(hash) {
+			continue
+		}
+
+		err = os.Remove(filepath.Join(rs.cacheDir, name))
+		if err != nil {
+			return pruned, err
+		}
+		pruned++
+	}
+	return pruned, nil
+}
+
+func formatOf(path string) Format {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".png":
+		return PNG
+	case ".webp":
+		return WebP
+	default:
+		return JPEG
+	}
+}
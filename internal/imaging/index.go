@@ -0,0 +1,130 @@
+package imaging
+
+import (
+	"fmt"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"os"
+
+	// the decoder of the format most of the website's images are stored in
+	_ "golang.org/x/image/webp"
+
+	"golang.org/x/image/draw"
+)
+
+// Decode reads the image at the given path, which may be a WebP, JPEG, or PNG.
+func Decode(path string) (image.Image, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	img, _, err := image.Decode(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode image `%s`: %w", path, err)
+	}
+	return img, nil
+}
+
+// Size reads the width and height of the image at the given path, without decoding all of it.
+func Size(path string) (int, int, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer f.Close()
+
+	config, _, err := image.DecodeConfig(f)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to decode image `%s`: %w", path, err)
+	}
+	return config.Width, config.Height, nil
+}
+
+// Fit scales the image down to fit within the given width and height, keeping its aspect ratio. Images that already
+// fit are returned as they are.
+func Fit(img image.Image, maxWidth, maxHeight int) image.Image {
+	return fit(img, maxWidth, maxHeight, draw.CatmullRom)
+}
+
+// fit scales the image down like Fit, with the given scaler.
+func fit(img image.Image, maxWidth, maxHeight int, scaler draw.Scaler) image.Image {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width <= maxWidth && height <= maxHeight {
+		return img
+	}
+
+	// scale by whichever side is furthest over
+	if width*maxHeight > height*maxWidth {
+		height = height * maxWidth / width
+		width = maxWidth
+	} else {
+		width = width * maxHeight / height
+		height = maxHeight
+	}
+	if width < 1 {
+		width = 1
+	}
+	if height < 1 {
+		height = 1
+	}
+
+	scaled := image.NewRGBA(image.Rect(0, 0, width, height))
+	scaler.Scale(scaled, scaled.Bounds(), img, bounds, draw.Src, nil)
+	return scaled
+}
+
+// Fill scales and crops the image to exactly the given width and height, keeping its aspect ratio by cutting off
+// whatever sticks out past the middle.
+func Fill(img image.Image, width, height int) image.Image {
+	bounds := img.Bounds()
+
+	// crop to the target aspect ratio, around the middle
+	crop := bounds
+	if bounds.Dx()*height > bounds.Dy()*width {
+		cropWidth := bounds.Dy() * width / height
+		crop.Min.X += (bounds.Dx() - cropWidth) / 2
+		crop.Max.X = crop.Min.X + cropWidth
+	} else {
+		cropHeight := bounds.Dx() * height / width
+		crop.Min.Y += (bounds.Dy() - cropHeight) / 2
+		crop.Max.Y = crop.Min.Y + cropHeight
+	}
+
+	filled := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.CatmullRom.Scale(filled, filled.Bounds(), img, crop, draw.Src, nil)
+	return filled
+}
+
+// WriteJPEG encodes the image as a JPEG of the given quality (1-100) to the given path, replacing what was there.
+func WriteJPEG(path string, img image.Image, quality int) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	err = jpeg.Encode(f, img, &jpeg.Options{Quality: quality})
+	if err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// WritePNG encodes the image as a PNG to the given path, replacing what was there.
+func WritePNG(path string, img image.Image) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	err = png.Encode(f, img)
+	if err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,100 @@
+package imaging
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+
+	"golang.org/x/image/draw"
+)
+
+const (
+	// placeholderSize is the largest a placeholder's width and height can be. Placeholders are blurred when they're
+	// shown, so a few pixels are enough to hint at the image, and they're small enough to inline into every page.
+	placeholderSize = 4
+
+	// dominantColorSampleSize is the largest the image is scaled down to before counting its colors.
+	dominantColorSampleSize = 64
+)
+
+// DominantColor returns the most common color of the image as a hex code (e.g. "#cfb991").
+//
+// Colors are counted in coarse buckets, so that slightly different shades of the same color count together, and the
+// average of the fullest bucket is returned. Transparent pixels aren't counted.
+func DominantColor(img image.Image) string {
+	sample := fit(img, dominantColorSampleSize, dominantColorSampleSize, draw.ApproxBiLinear)
+
+	type bucket struct {
+		count   int
+		r, g, b int
+	}
+	buckets := make(map[uint16]*bucket)
+	var fullest *bucket
+
+	bounds := sample.Bounds()
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			c := color.NRGBAModel.Convert(sample.At(x, y)).(color.NRGBA)
+			if c.A < 128 {
+				continue
+			}
+
+			// 3 bits per channel
+			key := uint16(c.R>>5)<<6 | uint16(c.G>>5)<<3 | uint16(c.B>>5)
+			b, ok := buckets[key]
+			if !ok {
+				b = &bucket{}
+				buckets[key] = b
+			}
+			b.count++
+			b.r += int(c.R)
+			b.g += int(c.G)
+			b.b += int(c.B)
+
+			if fullest == nil || b.count > fullest.count {
+				fullest = b
+			}
+		}
+	}
+
+	// an entirely transparent image has no color to speak of
+	if fullest == nil {
+		return "#000000"
+	}
+	return fmt.Sprintf("#%02x%02x%02x", fullest.r/fullest.count, fullest.g/fullest.count, fullest.b/fullest.count)
+}
+
+// Placeholder returns a tiny version of the image as a base64-encoded PNG data URL, to be shown (blurred) in its place
+// while it loads.
+func Placeholder(img image.Image) (string, error) {
+	// scaling a large image straight down to a few pixels is slow, and not worth doing well
+	sample := fit(img, placeholderSize*4, placeholderSize*4, draw.ApproxBiLinear)
+
+	var buf bytes.Buffer
+	encoder := &png.Encoder{CompressionLevel: png.BestCompression}
+	err := encoder.Encode(&buf, Fit(sample, placeholderSize, placeholderSize))
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("data:image/png;base64,%s", base64.StdEncoding.EncodeToString(buf.Bytes())), nil
+}
+
+// IsOpaque returns whether every pixel of the image is fully opaque.
+func IsOpaque(img image.Image) bool {
+	if o, ok := img.(interface{ Opaque() bool }); ok {
+		return o.Opaque()
+	}
+
+	bounds := img.Bounds()
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			if _, _, _, a := img.At(x, y).RGBA(); a != 0xffff {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,39 @@
+package inmemorydatabase
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/purdoobahs/purdoobahs.com/internal/achievements"
+)
+
+type AchievementService struct {
+	achievements map[string]*achievements.Achievement
+}
+
+func NewAchievementService(achievements map[string]*achievements.Achievement) *AchievementService {
+	return &AchievementService{
+		achievements: achievements,
+	}
+}
+
+// All returns every single Achievement, sorted by name.
+func (as *AchievementService) All() ([]*achievements.Achievement, error) {
+	allAchievements := make([]*achievements.Achievement, 0, len(as.achievements))
+
+	for _, v := range as.achievements {
+		allAchievements = append(allAchievements, v)
+	}
+
+	sort.Sort(achievements.ByName(allAchievements))
+	return allAchievements, nil
+}
+
+// ByID returns a single Achievement by the name of its asset file.
+func (as *AchievementService) ByID(id string) (*achievements.Achievement, error) {
+	if achievement, ok := as.achievements[id]; ok {
+		return achievement, nil
+	}
+
+	return &achievements.Achievement{}, fmt.Errorf("%w: `%s`", achievements.ErrAchievementNotFound, id)
+}
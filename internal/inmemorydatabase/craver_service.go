@@ -0,0 +1,39 @@
+package inmemorydatabase
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/purdoobahs/purdoobahs.com/internal/cravers"
+)
+
+type CraverService struct {
+	cravers map[string]*cravers.Craver
+}
+
+func NewCraverService(cravers map[string]*cravers.Craver) *CraverService {
+	return &CraverService{
+		cravers: cravers,
+	}
+}
+
+// All returns every single Craver, most recently inducted first.
+func (cs *CraverService) All() ([]*cravers.Craver, error) {
+	allCravers := make([]*cravers.Craver, 0, len(cs.cravers))
+
+	for _, v := range cs.cravers {
+		allCravers = append(allCravers, v)
+	}
+
+	sort.Sort(cravers.ByInduction(allCravers))
+	return allCravers, nil
+}
+
+// ByName returns a single Craver by the name of their asset file.
+func (cs *CraverService) ByName(name string) (*cravers.Craver, error) {
+	if craver, ok := cs.cravers[name]; ok {
+		return craver, nil
+	}
+
+	return &cravers.Craver{}, fmt.Errorf("%w: `%s`", cravers.ErrCraverNotFound, name)
+}
@@ -0,0 +1,39 @@
+package inmemorydatabase
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/purdoobahs/purdoobahs.com/internal/events"
+)
+
+type EventService struct {
+	events map[string]*events.Event
+}
+
+func NewEventService(events map[string]*events.Event) *EventService {
+	return &EventService{
+		events: events,
+	}
+}
+
+// All returns every single Event, earliest first.
+func (es *EventService) All() ([]*events.Event, error) {
+	allEvents := make([]*events.Event, 0, len(es.events))
+
+	for _, v := range es.events {
+		allEvents = append(allEvents, v)
+	}
+
+	sort.Sort(events.ByDate(allEvents))
+	return allEvents, nil
+}
+
+// ByName returns a single Event by the name of its asset file.
+func (es *EventService) ByName(name string) (*events.Event, error) {
+	if event, ok := es.events[name]; ok {
+		return event, nil
+	}
+
+	return &events.Event{}, fmt.Errorf("%w: `%s`", events.ErrEventNotFound, name)
+}
@@ -0,0 +1,68 @@
+package inmemorydatabase
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/purdoobahs/purdoobahs.com/internal/gallery"
+)
+
+type PhotoService struct {
+	photos map[string]*gallery.Photo
+}
+
+func NewPhotoService(photos map[string]*gallery.Photo) *PhotoService {
+	return &PhotoService{
+		photos: photos,
+	}
+}
+
+// All returns every single Photo, newest year first.
+func (ps *PhotoService) All() ([]*gallery.Photo, error) {
+	return ps.filter(func(p *gallery.Photo) bool {
+		return true
+	}), nil
+}
+
+// ByName returns a single Photo by the name of its asset file.
+func (ps *PhotoService) ByName(name string) (*gallery.Photo, error) {
+	if photo, ok := ps.photos[name]; ok {
+		return photo, nil
+	}
+
+	return &gallery.Photo{}, fmt.Errorf("%w: `%s`", gallery.ErrPhotoNotFound, name)
+}
+
+// ByYear returns every Photo taken in the given section year.
+func (ps *PhotoService) ByYear(year int) ([]*gallery.Photo, error) {
+	return ps.filter(func(p *gallery.Photo) bool {
+		return p.Year == year
+	}), nil
+}
+
+// ByTradition returns every Photo the tradition is tagged in, newest year first.
+func (ps *PhotoService) ByTradition(id string) ([]*gallery.Photo, error) {
+	return ps.filter(func(p *gallery.Photo) bool {
+		return p.HasTradition(id)
+	}), nil
+}
+
+// ByPurdoobah returns every Photo the Purdoobah is tagged in, newest year first.
+func (ps *PhotoService) ByPurdoobah(id string) ([]*gallery.Photo, error) {
+	return ps.filter(func(p *gallery.Photo) bool {
+		return p.HasPurdoobah(id)
+	}), nil
+}
+
+func (ps *PhotoService) filter(keep func(p *gallery.Photo) bool) []*gallery.Photo {
+	photos := make([]*gallery.Photo, 0)
+
+	for _, v := range ps.photos {
+		if keep(v) {
+			photos = append(photos, v)
+		}
+	}
+
+	sort.Sort(gallery.ByYear(photos))
+	return photos
+}
@@ -35,7 +35,7 @@ func (ps *PurdoobahService) ByName(name string) (*purdoobahs.Purdoobah, error) {
 		return purdoobah, nil
 	}
 
-	return &purdoobahs.Purdoobah{}, fmt.Errorf("no Purdoobah exists with that name")
+	return &purdoobahs.Purdoobah{}, fmt.Errorf("%w: `%s`", purdoobahs.ErrPurdoobahNotFound, name)
 }
 
 // CurrentSection returns all the Purdoobahs that are marching this academic year.
@@ -49,7 +49,7 @@ func (ps *PurdoobahService) CurrentSection() (*purdoobahs.Section, error) {
 		Freshmen:       make([]*purdoobahs.Purdoobah, 0),
 	}
 
-	currentAcademicYear := ps.currentAcademicYear()
+	currentAcademicYear := purdoobahs.CurrentAcademicYear()
 
 	for _, p := range ps.purdoobahs {
 		if !p.MarchedDuringYear(currentAcademicYear) {
@@ -81,16 +81,20 @@ func (ps *PurdoobahService) CurrentSection() (*purdoobahs.Section, error) {
 	return currentSection, nil
 }
 
-// SectionByYear returns all the Purdoobahs that marched during the given year.
+// SectionByYear returns all the Purdoobahs that marched during the given year, as they were that season.
 func (ps *PurdoobahService) SectionByYear(targetYear int) ([]*purdoobahs.Purdoobah, error) {
 	sectionByYear := make([]*purdoobahs.Purdoobah, 0)
 
 	for _, p := range ps.purdoobahs {
 		if p.MarchedDuringYear(targetYear) {
-			sectionByYear = append(sectionByYear, p)
+			sectionByYear = append(sectionByYear, p.AsOfSeason(targetYear))
 		}
 	}
 
+	if len(sectionByYear) == 0 {
+		return sectionByYear, fmt.Errorf("%w: `%d`", purdoobahs.ErrSectionNotFound, targetYear)
+	}
+
 	sort.Sort(purdoobahs.ByName(sectionByYear))
 	return sectionByYear, nil
 }
@@ -116,23 +120,3 @@ func (ps *PurdoobahService) AllSectionYears() ([]int, error) {
 	sort.Ints(uniqueYearsMarchedSlice)
 	return uniqueYearsMarchedSlice, nil
 }
-
-// currentAcademicYear returns the value of the current academic year.
-// e.g. if the academic year is "Fall 2020 -> Spring 2021", it will return "2020"
-func (ps *PurdoobahService) currentAcademicYear() int {
-	// t := time.Now()
-	// switch t.Month() {
-	// case time.January,
-	// 	time.February,
-	// 	time.March,
-	// 	time.April,
-	// 	time.May,
-	// 	time.June,
-	// 	time.July,
-	// 	time.August:
-	// 	return t.Year() - 1
-	// default:
-	// 	return t.Year()
-	// }
-	return 2020
-}
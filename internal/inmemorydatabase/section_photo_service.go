@@ -0,0 +1,26 @@
+package inmemorydatabase
+
+import (
+	"fmt"
+
+	"github.com/purdoobahs/purdoobahs.com/internal/sectionphotos"
+)
+
+type SectionPhotoService struct {
+	sectionPhotos map[int]*sectionphotos.SectionPhoto
+}
+
+func NewSectionPhotoService(sectionPhotos map[int]*sectionphotos.SectionPhoto) *SectionPhotoService {
+	return &SectionPhotoService{
+		sectionPhotos: sectionPhotos,
+	}
+}
+
+// ByYear returns the tagged photo of a single section.
+func (sps *SectionPhotoService) ByYear(year int) (*sectionphotos.SectionPhoto, error) {
+	if sectionPhoto, ok := sps.sectionPhotos[year]; ok {
+		return sectionPhoto, nil
+	}
+
+	return &sectionphotos.SectionPhoto{}, fmt.Errorf("%w: `%d`", sectionphotos.ErrSectionPhotoNotFound, year)
+}
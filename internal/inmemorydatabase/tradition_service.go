@@ -35,5 +35,5 @@ func (ts *TraditionService) ByName(name string) (*traditions.Tradition, error) {
 		return tradition, nil
 	}
 
-	return &traditions.Tradition{}, fmt.Errorf("no Tradition exists with that name")
+	return &traditions.Tradition{}, fmt.Errorf("%w: `%s`", traditions.ErrTraditionNotFound, name)
 }
@@ -0,0 +1,114 @@
+package jsondatabase
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/purdoobahs/purdoobahs.com/internal/cravers"
+	"github.com/purdoobahs/purdoobahs.com/internal/jsonstore"
+)
+
+// ApplicationService keeps Cravers Hall of Fame applications in a JSON file, which is rewritten after every change.
+type ApplicationService struct {
+	store *jsonstore.Store
+
+	mu           sync.Mutex
+	applications []*cravers.Application
+}
+
+func NewApplicationService(store *jsonstore.Store) (*ApplicationService, error) {
+	applications := make([]*cravers.Application, 0)
+	err := store.Load(&applications)
+	if err != nil {
+		return &ApplicationService{}, fmt.Errorf("failed to load Cravers applications from `%s`: %w", store.Path(), err)
+	}
+
+	return &ApplicationService{
+		store:        store,
+		applications: applications,
+	}, nil
+}
+
+// All returns every single application, pending ones first, then newest first.
+func (as *ApplicationService) All() ([]*cravers.Application, error) {
+	as.mu.Lock()
+	defer as.mu.Unlock()
+
+	applications := make([]*cravers.Application, 0, len(as.applications))
+	for _, a := range as.applications {
+		application := *a
+		applications = append(applications, &application)
+	}
+
+	sort.SliceStable(applications, func(i, j int) bool {
+		iPending, jPending := applications[i].Status == cravers.Pending, applications[j].Status == cravers.Pending
+		if iPending != jPending {
+			return iPending
+		}
+		return applications[i].SubmittedAt.After(applications[j].SubmittedAt)
+	})
+	return applications, nil
+}
+
+// Submit adds an application to the review queue.
+func (as *ApplicationService) Submit(a *cravers.Application) (*cravers.Application, error) {
+	as.mu.Lock()
+	defer as.mu.Unlock()
+
+	b := make([]byte, 6)
+	_, err := rand.Read(b)
+	if err != nil {
+		return &cravers.Application{}, err
+	}
+
+	application := *a
+	application.ID = hex.EncodeToString(b)
+	application.SubmittedAt = time.Now().UTC()
+	application.Status = cravers.Pending
+	application.ReviewedAt = nil
+
+	as.applications = append(as.applications, &application)
+	err = as.store.Save(as.applications)
+	if err != nil {
+		as.applications = as.applications[:len(as.applications)-1]
+		return &cravers.Application{}, err
+	}
+
+	submitted := application
+	return &submitted, nil
+}
+
+// Review records the outcome of an application.
+func (as *ApplicationService) Review(id string, status cravers.ApplicationStatus) (*cravers.Application, error) {
+	as.mu.Lock()
+	defer as.mu.Unlock()
+
+	for _, a := range as.applications {
+		if a.ID != id {
+			continue
+		}
+
+		previous := *a
+		now := time.Now().UTC()
+		a.Status = status
+		a.ReviewedAt = &now
+		if status == cravers.Pending {
+			a.ReviewedAt = nil
+		}
+
+		err := as.store.Save(as.applications)
+		if err != nil {
+			*a = previous
+			return &cravers.Application{}, err
+		}
+
+		reviewed := *a
+		return &reviewed, nil
+	}
+
+	return &cravers.Application{}, fmt.Errorf("%w: `%s`", cravers.ErrApplicationNotFound, id)
+}
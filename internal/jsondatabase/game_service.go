@@ -0,0 +1,156 @@
+package jsondatabase
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/purdoobahs/purdoobahs.com/internal/jsonstore"
+	"github.com/purdoobahs/purdoobahs.com/internal/toobahsassins"
+)
+
+// GameService keeps Toobahsassins games in a JSON file, which is rewritten after every change.
+type GameService struct {
+	store *jsonstore.Store
+
+	mu    sync.Mutex
+	games map[string]*toobahsassins.Game
+}
+
+func NewGameService(store *jsonstore.Store) (*GameService, error) {
+	games := make([]*toobahsassins.Game, 0)
+	err := store.Load(&games)
+	if err != nil {
+		return &GameService{}, fmt.Errorf("failed to load Toobahsassins games from `%s`: %w", store.Path(), err)
+	}
+
+	gs := &GameService{
+		store: store,
+		games: make(map[string]*toobahsassins.Game, len(games)),
+	}
+	for _, g := range games {
+		gs.games[g.ID] = g
+	}
+	return gs, nil
+}
+
+// All returns every single game, newest first.
+func (gs *GameService) All() ([]*toobahsassins.Game, error) {
+	gs.mu.Lock()
+	defer gs.mu.Unlock()
+
+	return gs.sorted(), nil
+}
+
+// ByID returns a single game by its ID.
+func (gs *GameService) ByID(id string) (*toobahsassins.Game, error) {
+	gs.mu.Lock()
+	defer gs.mu.Unlock()
+
+	if g, ok := gs.games[id]; ok {
+		return g.Clone(), nil
+	}
+
+	return &toobahsassins.Game{}, fmt.Errorf("%w: `%s`", toobahsassins.ErrGameNotFound, id)
+}
+
+// Create starts a new game between the given Purdoobahs.
+func (gs *GameService) Create(season int, playerIDs []string) (*toobahsassins.Game, error) {
+	gs.mu.Lock()
+	defer gs.mu.Unlock()
+
+	id, err := newGameID()
+	if err != nil {
+		return &toobahsassins.Game{}, err
+	}
+
+	g, err := toobahsassins.NewGame(id, season, playerIDs, time.Now().UTC())
+	if err != nil {
+		return &toobahsassins.Game{}, err
+	}
+
+	gs.games[g.ID] = g
+	err = gs.save()
+	if err != nil {
+		delete(gs.games, g.ID)
+		return &toobahsassins.Game{}, err
+	}
+
+	return g.Clone(), nil
+}
+
+// Eliminate records the assassin eliminating their current target.
+func (gs *GameService) Eliminate(gameID, assassinID string) (*toobahsassins.Game, error) {
+	gs.mu.Lock()
+	defer gs.mu.Unlock()
+
+	g, ok := gs.games[gameID]
+	if !ok {
+		return &toobahsassins.Game{}, fmt.Errorf("%w: `%s`", toobahsassins.ErrGameNotFound, gameID)
+	}
+
+	// only keep the elimination if it's been saved
+	updated := g.Clone()
+	_, err := updated.Eliminate(assassinID, time.Now().UTC())
+	if err != nil {
+		return &toobahsassins.Game{}, err
+	}
+
+	gs.games[gameID] = updated
+	err = gs.save()
+	if err != nil {
+		gs.games[gameID] = g
+		return &toobahsassins.Game{}, err
+	}
+
+	return updated.Clone(), nil
+}
+
+// Victories returns the seasons of every finished game by its victor's Purdoobah ID, oldest first.
+func (gs *GameService) Victories() (map[string][]int, error) {
+	gs.mu.Lock()
+	defer gs.mu.Unlock()
+
+	victories := make(map[string][]int)
+	for _, g := range gs.games {
+		if g.IsOver() {
+			victories[g.Victor] = append(victories[g.Victor], g.Season)
+		}
+	}
+	for _, seasons := range victories {
+		sort.Ints(seasons)
+	}
+	return victories, nil
+}
+
+// sorted returns copies of every game, newest first.
+func (gs *GameService) sorted() []*toobahsassins.Game {
+	games := make([]*toobahsassins.Game, 0, len(gs.games))
+	for _, g := range gs.games {
+		games = append(games, g.Clone())
+	}
+
+	sort.Slice(games, func(i, j int) bool {
+		if games[i].Season != games[j].Season {
+			return games[i].Season > games[j].Season
+		}
+		return games[i].CreatedAt.After(games[j].CreatedAt)
+	})
+	return games
+}
+
+func (gs *GameService) save() error {
+	return gs.store.Save(gs.sorted())
+}
+
+func newGameID() (string, error) {
+	b := make([]byte, 6)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
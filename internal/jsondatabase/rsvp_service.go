@@ -0,0 +1,150 @@
+package jsondatabase
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/purdoobahs/purdoobahs.com/internal/jsonstore"
+	"github.com/purdoobahs/purdoobahs.com/internal/rsvp"
+)
+
+// RSVPService keeps event RSVPs in a JSON file, which is rewritten after every change.
+type RSVPService struct {
+	store *jsonstore.Store
+
+	mu    sync.Mutex
+	rsvps []*rsvp.RSVP
+}
+
+func NewRSVPService(store *jsonstore.Store) (*RSVPService, error) {
+	rsvps := make([]*rsvp.RSVP, 0)
+	err := store.Load(&rsvps)
+	if err != nil {
+		return &RSVPService{}, fmt.Errorf("failed to load RSVPs from `%s`: %w", store.Path(), err)
+	}
+
+	return &RSVPService{
+		store: store,
+		rsvps: rsvps,
+	}, nil
+}
+
+// ByEvent returns every RSVP to the event, in the order they were submitted.
+func (rs *RSVPService) ByEvent(eventID string) ([]*rsvp.RSVP, error) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	rsvps := make([]*rsvp.RSVP, 0)
+	for _, r := range rs.rsvps {
+		if r.Event == eventID {
+			copied := *r
+			rsvps = append(rsvps, &copied)
+		}
+	}
+	return rsvps, nil
+}
+
+// Submit adds an RSVP to the end of its event's list, seating it if there's room (see rsvp.Seat), unless the same
+// person has already RSVPed to it.
+func (rs *RSVPService) Submit(r *rsvp.RSVP, capacity int) (*rsvp.RSVP, error) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	for _, existing := range rs.rsvps {
+		if existing.Event == r.Event && existing.IsSamePerson(r) {
+			return &rsvp.RSVP{}, fmt.Errorf("%w: `%s`", rsvp.ErrAlreadyRSVPed, r.Event)
+		}
+	}
+
+	b := make([]byte, 6)
+	_, err := rand.Read(b)
+	if err != nil {
+		return &rsvp.RSVP{}, err
+	}
+
+	submitted := *r
+	submitted.ID = hex.EncodeToString(b)
+	submitted.SubmittedAt = time.Now().UTC()
+	submitted.Status = ""
+
+	updated := append(rs.copies(), &submitted)
+	_, err = rs.seat(updated, r.Event, capacity)
+	if err != nil {
+		return &rsvp.RSVP{}, err
+	}
+
+	copied := submitted
+	return &copied, nil
+}
+
+// Cancel removes an RSVP from its event's list, and seats as many of the waitlist as now fit, returning who was seated.
+func (rs *RSVPService) Cancel(eventID, id string, capacity int) ([]*rsvp.RSVP, error) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	for i, r := range rs.rsvps {
+		if r.Event != eventID || r.ID != id {
+			continue
+		}
+
+		updated := rs.copies()
+		updated = append(updated[:i], updated[i+1:]...)
+		return rs.seat(updated, eventID, capacity)
+	}
+
+	return nil, fmt.Errorf("%w: `%s`", rsvp.ErrRSVPNotFound, id)
+}
+
+// Settle seats as many of the event's waitlist as fit, returning who was seated. It's needed when an event gets bigger,
+// and for RSVPs saved before seats were, which haven't been seated yet.
+func (rs *RSVPService) Settle(eventID string, capacity int) ([]*rsvp.RSVP, error) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	return rs.seat(rs.copies(), eventID, capacity)
+}
+
+// seat hands out the event's seats within the updated RSVPs, then saves them, returning the RSVPs that were seated. The
+// RSVPs in memory are only replaced once they've been saved.
+func (rs *RSVPService) seat(updated []*rsvp.RSVP, eventID string, capacity int) ([]*rsvp.RSVP, error) {
+	event := make([]*rsvp.RSVP, 0)
+	for _, r := range updated {
+		if r.Event == eventID {
+			event = append(event, r)
+		}
+	}
+
+	changed := rsvp.Seat(event, capacity)
+	seated := make([]*rsvp.RSVP, 0)
+	for _, r := range changed {
+		if r.Status == rsvp.Attending {
+			copied := *r
+			seated = append(seated, &copied)
+		}
+	}
+
+	// nothing was added, removed, or seated, so there's nothing to save
+	if len(changed) == 0 && len(updated) == len(rs.rsvps) {
+		return seated, nil
+	}
+
+	err := rs.store.Save(updated)
+	if err != nil {
+		return nil, err
+	}
+	rs.rsvps = updated
+	return seated, nil
+}
+
+// copies copies every RSVP, so that they can be changed without touching the ones in memory.
+func (rs *RSVPService) copies() []*rsvp.RSVP {
+	copies := make([]*rsvp.RSVP, 0, len(rs.rsvps)+1)
+	for _, r := range rs.rsvps {
+		copied := *r
+		copies = append(copies, &copied)
+	}
+	return copies
+}
@@ -0,0 +1,112 @@
+package jsondatabase
+
+import (
+	"testing"
+
+	"github.com/purdoobahs/purdoobahs.com/internal/jsonstore"
+	"github.com/purdoobahs/purdoobahs.com/internal/rsvp"
+)
+
+const testCapacity = 10
+
+func newTestRSVPService(t *testing.T) *RSVPService {
+	t.Helper()
+
+	store, err := jsonstore.NewStore(t.TempDir(), "rsvps.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	rs, err := NewRSVPService(store)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return rs
+}
+
+// submit RSVPs a party of the given size, returning its ID.
+func submit(t *testing.T, rs *RSVPService, name string, partySize int) string {
+	t.Helper()
+
+	r, err := rs.Submit(&rsvp.RSVP{Event: "game-day", Name: name, Guests: partySize - 1}, testCapacity)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return r.ID
+}
+
+func statuses(t *testing.T, rs *RSVPService) map[string]rsvp.Status {
+	t.Helper()
+
+	rsvps, err := rs.ByEvent("game-day")
+	if err != nil {
+		t.Fatal(err)
+	}
+	statuses := make(map[string]rsvp.Status)
+	for _, r := range rsvps {
+		statuses[r.Name] = r.Status
+	}
+	return statuses
+}
+
+func expectStatuses(t *testing.T, rs *RSVPService, expected map[string]rsvp.Status) {
+	t.Helper()
+
+	actual := statuses(t, rs)
+	for name, status := range expected {
+		if actual[name] != status {
+			t.Errorf("expected `%s` to be %s, but they're %s", name, status, actual[name])
+		}
+	}
+}
+
+func TestSubmitDoesNotSkipAheadOfTheWaitlist(t *testing.T) {
+	rs := newTestRSVPService(t)
+
+	a := submit(t, rs, "a", 3)
+	submit(t, rs, "b", 8)
+	submit(t, rs, "c", 7)
+	expectStatuses(t, rs, map[string]rsvp.Status{"a": rsvp.Attending, "b": rsvp.Waitlisted, "c": rsvp.Waitlisted})
+
+	// b is at the front of the line, and c still doesn't fit behind them
+	_, err := rs.Cancel("game-day", a, testCapacity)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectStatuses(t, rs, map[string]rsvp.Status{"b": rsvp.Attending, "c": rsvp.Waitlisted})
+}
+
+func TestCancelNeverTakesAwayASeat(t *testing.T) {
+	rs := newTestRSVPService(t)
+
+	a := submit(t, rs, "a", 3)
+	submit(t, rs, "c", 7)
+	submit(t, rs, "b", 8)
+	expectStatuses(t, rs, map[string]rsvp.Status{"a": rsvp.Attending, "c": rsvp.Attending, "b": rsvp.Waitlisted})
+
+	seated, err := rs.Cancel("game-day", a, testCapacity)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(seated) != 0 {
+		t.Errorf("expected nobody to be seated, but %d were", len(seated))
+	}
+	expectStatuses(t, rs, map[string]rsvp.Status{"c": rsvp.Attending, "b": rsvp.Waitlisted})
+}
+
+func TestSettleSeatsTheWaitlistWhenAnEventGetsBigger(t *testing.T) {
+	rs := newTestRSVPService(t)
+
+	submit(t, rs, "a", 6)
+	submit(t, rs, "b", 6)
+	submit(t, rs, "c", 2)
+	expectStatuses(t, rs, map[string]rsvp.Status{"a": rsvp.Attending, "b": rsvp.Waitlisted, "c": rsvp.Waitlisted})
+
+	seated, err := rs.Settle("game-day", 20)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(seated) != 2 {
+		t.Errorf("expected 2 to be seated, but %d were", len(seated))
+	}
+	expectStatuses(t, rs, map[string]rsvp.Status{"a": rsvp.Attending, "b": rsvp.Attending, "c": rsvp.Attending})
+}
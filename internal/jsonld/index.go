@@ -0,0 +1,168 @@
+package jsonld
+
+import (
+	"fmt"
+
+	"github.com/purdoobahs/purdoobahs.com/internal/purdoobahs"
+	"github.com/purdoobahs/purdoobahs.com/internal/traditions"
+)
+
+// Context is the vocabulary every document is described with.
+const Context = "https://schema.org"
+
+// unknown is the placeholder the asset files use for details that have been lost to time.
+const unknown = "unknown"
+
+// band is the organization every Purdoobah is a member of.
+var band = &Organization{
+	Type: "MusicGroup",
+	Name: "Purdue All-American Marching Band",
+}
+
+// Organization is a schema.org Organization (or one of its subtypes).
+type Organization struct {
+	Context string    `json:"@context,omitempty"`
+	Type    string    `json:"@type"`
+	ID      string    `json:"@id,omitempty"`
+	Name    string    `json:"name"`
+	URL     string    `json:"url,omitempty"`
+	Image   string    `json:"image,omitempty"`
+	Member  []*Person `json:"member,omitempty"`
+
+	ParentOrganization *Organization `json:"parentOrganization,omitempty"`
+}
+
+// Person is a schema.org Person.
+type Person struct {
+	Context       string          `json:"@context,omitempty"`
+	Type          string          `json:"@type"`
+	ID            string          `json:"@id"`
+	Name          string          `json:"name"`
+	AlternateName string          `json:"alternateName,omitempty"`
+	URL           string          `json:"url"`
+	Image         string          `json:"image,omitempty"`
+	JobTitle      string          `json:"jobTitle,omitempty"`
+	HomeLocation  *Place          `json:"homeLocation,omitempty"`
+	MemberOf      []*Organization `json:"memberOf,omitempty"`
+	SameAs        []string        `json:"sameAs,omitempty"`
+}
+
+// Place is a schema.org Place.
+type Place struct {
+	Type    string         `json:"@type"`
+	Address *PostalAddress `json:"address"`
+}
+
+// PostalAddress is a schema.org PostalAddress.
+type PostalAddress struct {
+	Type            string `json:"@type"`
+	AddressLocality string `json:"addressLocality"`
+	AddressRegion   string `json:"addressRegion"`
+	AddressCountry  string `json:"addressCountry"`
+}
+
+// Thing is a schema.org Thing, the most generic type of item.
+type Thing struct {
+	Context     string `json:"@context,omitempty"`
+	Type        string `json:"@type"`
+	ID          string `json:"@id"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	URL         string `json:"url"`
+	Image       string `json:"image,omitempty"`
+}
+
+// NewPerson describes a Purdoobah. baseURL is the origin that links are made absolute with
+// (e.g. "https://www.purdoobahs.com").
+func NewPerson(p *purdoobahs.Purdoobah, baseURL string) *Person {
+	url := fmt.Sprintf("%s/purdoobah/%s", baseURL, p.ID)
+
+	person := &Person{
+		Context:  Context,
+		Type:     "Person",
+		ID:       url,
+		Name:     p.Name,
+		URL:      url,
+		JobTitle: p.JobTitle(),
+		MemberOf: []*Organization{band},
+	}
+	if p.BirthCertificateName.First != unknown && p.BirthCertificateName.Last != unknown {
+		person.AlternateName = p.FullName()
+	}
+	if p.Metadata.Image.File != "" {
+		person.Image = baseURL + p.Metadata.Image.File
+	}
+	if p.Hometown.City != unknown && p.Hometown.State != unknown {
+		person.HomeLocation = &Place{
+			Type: "Place",
+			Address: &PostalAddress{
+				Type:            "PostalAddress",
+				AddressLocality: p.Hometown.City,
+				AddressRegion:   p.Hometown.State,
+				AddressCountry:  "US",
+			},
+		}
+	}
+	for _, social := range []string{
+		p.Personal.Socials.Facebook,
+		p.Personal.Socials.Instagram,
+		p.Personal.Socials.LinkedIn,
+	} {
+		if social != "" {
+			person.SameAs = append(person.SameAs, social)
+		}
+	}
+
+	return person
+}
+
+// NewSection describes the section of a single year. image is the section photo, or empty if there is none.
+//
+// Members are embedded without their own @context, as they inherit the section's.
+func NewSection(year int, members []*purdoobahs.Purdoobah, image, baseURL string) *Organization {
+	url := fmt.Sprintf("%s/section/%d", baseURL, year)
+	name := fmt.Sprintf("%d Purdoobah Section", year)
+	if year == -1 {
+		url = fmt.Sprintf("%s/section/unknown", baseURL)
+		name = "Purdoobahs of Unknown Years"
+	}
+
+	section := &Organization{
+		Context:            Context,
+		Type:               "MusicGroup",
+		ID:                 url,
+		Name:               name,
+		URL:                url,
+		Member:             make([]*Person, 0, len(members)),
+		ParentOrganization: band,
+	}
+	if image != "" {
+		section.Image = baseURL + image
+	}
+	for _, member := range members {
+		person := NewPerson(member, baseURL)
+		person.Context = ""
+		section.Member = append(section.Member, person)
+	}
+
+	return section
+}
+
+// NewTradition describes a tradition.
+func NewTradition(t *traditions.Tradition, baseURL string) *Thing {
+	url := fmt.Sprintf("%s/tradition/%s", baseURL, t.ID)
+
+	tradition := &Thing{
+		Context:     Context,
+		Type:        "Thing",
+		ID:          url,
+		Name:        t.Name,
+		Description: t.Description,
+		URL:         url,
+	}
+	if t.Metadata.Image.File != "" {
+		tradition.Image = baseURL + t.Metadata.Image.File
+	}
+
+	return tradition
+}
@@ -1,6 +1,7 @@
 package jsonschema
 
 import (
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"os"
@@ -12,105 +13,68 @@ import (
 	"github.com/xeipuuv/gojsonschema"
 )
 
-func ValidateJsonSchema(logger logger.ILogger) (bool, error) {
-	invalidFiles, err := validatePurdoobahJsonSchema(logger)
-	if err != nil {
-		return invalidFiles, err
-	}
-
-	invalidFiles, err = validateTraditionJsonSchema(logger)
-	if err != nil {
-		return invalidFiles, err
-	}
-
-	// no invalid files
-	return false, nil
+// collections are the asset directories, and the JSON Schema each of their files has to match.
+var collections = []struct {
+	schemaPath string
+	dir        string
+}{
+	{"./assets/purdoobahs/_purdoobah.schema.json", "./assets/purdoobahs/"},
+	{"./assets/traditions/_tradition.schema.json", "./assets/traditions/"},
+	{"./assets/cravers/_craver.schema.json", "./assets/cravers/"},
+	{"./assets/achievements/_achievement.schema.json", "./assets/achievements/"},
+	{"./assets/events/_event.schema.json", "./assets/events/"},
+	{"./assets/photos/_photo.schema.json", "./assets/photos/"},
+	{"./assets/section-photos/_section-photo.schema.json", "./assets/section-photos/"},
 }
 
-func validatePurdoobahJsonSchema(logger logger.ILogger) (bool, error) {
-	// read in the Purdoobah JSON Schema
-	purdoobahJSONSchemaFilepath := "./assets/purdoobahs/_purdoobah.schema.json"
-	b, err := ioutil.ReadFile(purdoobahJSONSchemaFilepath)
-	if err != nil {
-		logger.Error(fmt.Sprintf(
-			"error reading file: %s",
-			"./assets/purdoobahs/_purdoobah.schema.json"),
-		)
-		return true, err
-	}
-	schema := gojsonschema.NewStringLoader(string(b))
-
-	// find all the individual Purdoobah files
-	filepaths, err := walkMatch("./assets/purdoobahs/", `*.json`)
-	if err != nil {
-		logger.Error("error parsing Purdoobah assets directory")
-		return true, err
-	}
-
-	// loop through each file
+// ValidateJsonSchema validates every asset collection against its JSON Schema, returning whether any file is invalid.
+//
+// Every collection is validated, even after one of them fails, so that all the problems are reported in a single run.
+// Any invalid file stops startup, in every collection.
+func ValidateJsonSchema(logger logger.ILogger) (bool, error) {
 	invalidFiles := false
-	for _, path := range filepaths {
-		// ignore _purdoobah.schema.json and _template.json
-		if strings.Contains(path, "_") {
-			continue
-		}
-
-		// read in the Purdoobah JSON document
-		b, err := ioutil.ReadFile(path)
+	problems := make([]string, 0)
+	for _, collection := range collections {
+		invalid, err := validateDirectory(logger, collection.schemaPath, collection.dir)
 		if err != nil {
-			logger.Error(fmt.Sprintf("error reading file: %s", path))
-			return true, err
-		}
-		document := gojsonschema.NewStringLoader(string(b))
-
-		// validate the document against the schema
-		result, err := gojsonschema.Validate(schema, document)
-		if err != nil {
-			logger.Error(fmt.Sprintf("error validating file: %s", path))
-			return true, err
-		}
-
-		// if not valid, print errors
-		if !result.Valid() {
-			invalidFiles = true
-			for _, desc := range result.Errors() {
-				logger.Error(fmt.Sprintf("validation error (%s): %s", path, desc))
-			}
+			problems = append(problems, err.Error())
 		}
+		invalidFiles = invalidFiles || invalid
 	}
 
+	if len(problems) > 0 {
+		return true, errors.New(strings.Join(problems, "; "))
+	}
 	return invalidFiles, nil
 }
 
-func validateTraditionJsonSchema(logger logger.ILogger) (bool, error) {
-	// read in the Tradition JSON Schema
-	traditionJSONSchemaFilepath := "./assets/traditions/_tradition.schema.json"
-	b, err := ioutil.ReadFile(traditionJSONSchemaFilepath)
+// validateDirectory validates every JSON file in dir against the JSON Schema at schemaPath, logging each validation
+// error and returning whether any file is invalid. Files with an underscore in their name (e.g. the schema itself
+// and _template.json) are skipped.
+func validateDirectory(logger logger.ILogger, schemaPath, dir string) (bool, error) {
+	// read in the JSON Schema
+	b, err := ioutil.ReadFile(schemaPath)
 	if err != nil {
-		logger.Error(fmt.Sprintf(
-			"error reading file: %s",
-			"./assets/traditions/_tradition.schema.json"),
-		)
+		logger.Error(fmt.Sprintf("error reading file: %s", schemaPath))
 		return true, err
 	}
 	schema := gojsonschema.NewStringLoader(string(b))
 
-	// find all the individual Tradition files
-	filepaths, err := walkMatch("./assets/traditions/", `*.json`)
+	// find all the individual asset files
+	filepaths, err := walkMatch(dir, `*.json`)
 	if err != nil {
-		logger.Error("error parsing Tradition assets directory")
+		logger.Error(fmt.Sprintf("error parsing assets directory: %s", dir))
 		return true, err
 	}
 
 	// loop through each file
 	invalidFiles := false
 	for _, path := range filepaths {
-		// ignore _tradition.schema.json and _template.json
-		if strings.Contains(path, "_") {
+		if strings.Contains(filepath.Base(path), "_") {
 			continue
 		}
 
-		// read in the Tradition JSON document
+		// read in the JSON document
 		b, err := ioutil.ReadFile(path)
 		if err != nil {
 			logger.Error(fmt.Sprintf("error reading file: %s", path))
@@ -0,0 +1,84 @@
+package jsonstore
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Store persists a single value as a JSON file on the local disk.
+//
+// Every save rewrites the whole file atomically (write to a temporary file, then rename), so a crash mid-write never
+// leaves a corrupt file behind. Stores are meant for small amounts of data that change rarely, e.g. a handful of games
+// or form submissions, not as a general purpose database.
+type Store struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewStore creates a store for the file `name` within `dir`, creating the directory if it doesn't exist yet.
+func NewStore(dir, name string) (*Store, error) {
+	err := os.MkdirAll(dir, 0o755)
+	if err != nil {
+		return &Store{}, err
+	}
+
+	return &Store{path: filepath.Join(dir, name)}, nil
+}
+
+// Path returns the location of the store's file.
+func (s *Store) Path() string {
+	return s.path
+}
+
+// Load reads the stored value into v. If nothing has been saved yet, v is left untouched.
+func (s *Store) Load(v interface{}) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	b, err := ioutil.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(b, v)
+}
+
+// Save replaces the stored value with v.
+func (s *Store) Save(v interface{}) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	b, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := ioutil.TempFile(filepath.Dir(s.path), filepath.Base(s.path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	_, err = tmp.Write(append(b, '\n'))
+	if err != nil {
+		tmp.Close()
+		return err
+	}
+	err = tmp.Sync()
+	if err != nil {
+		tmp.Close()
+		return err
+	}
+	err = tmp.Close()
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), s.path)
+}
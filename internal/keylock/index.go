@@ -0,0 +1,57 @@
+package keylock
+
+import (
+	"sync"
+)
+
+// KeyLock is a set of mutexes, one per key, for work that mustn't be done twice at once (e.g. drawing the same image)
+// but may be done for different keys in parallel.
+//
+// A key's mutex only exists while it's held or waited on, so that locking many different keys doesn't grow the set.
+type KeyLock struct {
+	mu    sync.Mutex
+	locks map[string]*lock
+}
+
+type lock struct {
+	sync.Mutex
+
+	// holders is how many goroutines hold or are waiting on the mutex
+	holders int
+}
+
+func NewKeyLock() *KeyLock {
+	return &KeyLock{
+		locks: make(map[string]*lock),
+	}
+}
+
+// Lock locks the key, waiting until it's unlocked if it's already locked.
+func (kl *KeyLock) Lock(key string) {
+	kl.mu.Lock()
+	l, ok := kl.locks[key]
+	if !ok {
+		l = &lock{}
+		kl.locks[key] = l
+	}
+	l.holders++
+	kl.mu.Unlock()
+
+	l.Lock()
+}
+
+// Unlock unlocks the key, forgetting it if nobody else is waiting on it.
+func (kl *KeyLock) Unlock(key string) {
+	kl.mu.Lock()
+	defer kl.mu.Unlock()
+
+	l, ok := kl.locks[key]
+	if !ok {
+		panic("keylock: unlock of unlocked key")
+	}
+	l.holders--
+	if l.holders == 0 {
+		delete(kl.locks, key)
+	}
+	l.Unlock()
+}
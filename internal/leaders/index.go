@@ -0,0 +1,75 @@
+package leaders
+
+import (
+	"sort"
+
+	"github.com/purdoobahs/purdoobahs.com/internal/purdoobahs"
+)
+
+// Leader is a Purdoobah holding a leadership role during a single year.
+type Leader struct {
+	// Purdoobah is as they were during the year they led.
+	Purdoobah *purdoobahs.Purdoobah `json:"purdoobah"`
+
+	Role      purdoobahs.LeadershipRole `json:"role"`
+	Committee string                    `json:"committee,omitempty"`
+	Title     string                    `json:"title"`
+
+	// Term is the whole consecutive run of years this Purdoobah held the role, including this year.
+	Term purdoobahs.LeadershipTerm `json:"term"`
+}
+
+// Year is everybody who held a leadership role during a single year.
+type Year struct {
+	Year    int       `json:"year"`
+	Leaders []*Leader `json:"leaders"`
+}
+
+// NewHistory lists the section's leaders year by year, newest first. Within a year, the most senior roles come first.
+func NewHistory(purdoobahService purdoobahs.IPurdoobahService) ([]*Year, error) {
+	allPurdoobahs, err := purdoobahService.All()
+	if err != nil {
+		return nil, err
+	}
+
+	byYear := make(map[int]*Year)
+	for _, p := range allPurdoobahs {
+		for _, l := range p.Achievements.Leadership {
+			for _, term := range l.Terms() {
+				for year := term.Start; year <= term.End; year++ {
+					y, ok := byYear[year]
+					if !ok {
+						y = &Year{Year: year, Leaders: make([]*Leader, 0)}
+						byYear[year] = y
+					}
+
+					y.Leaders = append(y.Leaders, &Leader{
+						Purdoobah: p.AsOfSeason(year),
+						Role:      l.Role,
+						Committee: l.Committee,
+						Title:     l.Title(),
+						Term:      term,
+					})
+				}
+			}
+		}
+	}
+
+	history := make([]*Year, 0, len(byYear))
+	for _, y := range byYear {
+		sort.SliceStable(y.Leaders, func(i, j int) bool {
+			a := purdoobahs.Leadership{Role: y.Leaders[i].Role, Committee: y.Leaders[i].Committee}
+			b := purdoobahs.Leadership{Role: y.Leaders[j].Role, Committee: y.Leaders[j].Committee}
+			if a.Outranks(b) || b.Outranks(a) {
+				return a.Outranks(b)
+			}
+			return y.Leaders[i].Purdoobah.Name < y.Leaders[j].Purdoobah.Name
+		})
+		history = append(history, y)
+	}
+
+	sort.Slice(history, func(i, j int) bool {
+		return history[i].Year > history[j].Year
+	})
+	return history, nil
+}
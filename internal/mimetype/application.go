@@ -7,10 +7,12 @@ func (a Application) String() string {
 }
 
 const (
+	GeoJson               Application = "application/geo+json"
 	Gzip                  Application = "application/gzip"
 	JavascriptApplication Application = "application/javascript"
 	Json                  Application = "application/json"
 	LdJson                Application = "application/ld+json"
+	Ndjson                Application = "application/x-ndjson"
 	OggApplication        Application = "application/ogg"
 	Pdf                   Application = "application/pdf"
 	XTar                  Application = "application/x-tar"
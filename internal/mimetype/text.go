@@ -13,5 +13,6 @@ const (
 	Calendar       Text = "text/calendar"
 	JavascriptText Text = "text/javascript"
 	Plain          Text = "text/plain"
+	VCard          Text = "text/vcard"
 	XmlText        Text = "text/xml"
 )
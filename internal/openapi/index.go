@@ -0,0 +1,284 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strings"
+)
+
+// Version is the version of the OpenAPI Specification this package generates.
+//
+// OpenAPI 3.1 is a superset of JSON Schema 2020-12, which is what our asset schemas are written in.
+const Version = "3.1.0"
+
+// Schema is a JSON Schema document.
+type Schema map[string]interface{}
+
+type Document struct {
+	OpenAPI    string               `json:"openapi"`
+	Info       Info                 `json:"info"`
+	Servers    []Server             `json:"servers,omitempty"`
+	Tags       []Tag                `json:"tags,omitempty"`
+	Paths      map[string]*PathItem `json:"paths"`
+	Components Components           `json:"components"`
+}
+
+type Info struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Version     string `json:"version"`
+}
+
+type Server struct {
+	URL         string `json:"url"`
+	Description string `json:"description,omitempty"`
+}
+
+type Tag struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+type PathItem struct {
+	Get  *Operation `json:"get,omitempty"`
+	Post *Operation `json:"post,omitempty"`
+}
+
+type Operation struct {
+	OperationID string               `json:"operationId"`
+	Summary     string               `json:"summary"`
+	Description string               `json:"description,omitempty"`
+	Tags        []string             `json:"tags,omitempty"`
+	Parameters  []Parameter          `json:"parameters,omitempty"`
+	RequestBody *RequestBody         `json:"requestBody,omitempty"`
+	Responses   map[string]*Response `json:"responses"`
+}
+
+type Parameter struct {
+	Name        string `json:"name"`
+	In          string `json:"in"`
+	Description string `json:"description,omitempty"`
+	Required    bool   `json:"required"`
+	Schema      Schema `json:"schema"`
+	Example     string `json:"example,omitempty"`
+}
+
+type RequestBody struct {
+	Required bool                  `json:"required"`
+	Content  map[string]*MediaType `json:"content"`
+}
+
+type Response struct {
+	Description string                `json:"description"`
+	Content     map[string]*MediaType `json:"content,omitempty"`
+}
+
+type MediaType struct {
+	Schema Schema `json:"schema"`
+}
+
+type Components struct {
+	Schemas map[string]Schema `json:"schemas"`
+}
+
+// Endpoint is a single documented operation.
+type Endpoint struct {
+	Method    string
+	Path      string
+	Operation *Operation
+}
+
+// Property is a flattened, human-readable view of a single schema property.
+type Property struct {
+	Name        string
+	Type        string
+	Description string
+	Required    bool
+}
+
+func NewDocument(info Info) *Document {
+	return &Document{
+		OpenAPI: Version,
+		Info:    info,
+		Paths:   make(map[string]*PathItem),
+		Components: Components{
+			Schemas: make(map[string]Schema),
+		},
+	}
+}
+
+// AddOperation documents the operation found at the given path and HTTP method.
+func (d *Document) AddOperation(path, method string, operation *Operation) error {
+	pathItem, ok := d.Paths[path]
+	if !ok {
+		pathItem = &PathItem{}
+		d.Paths[path] = pathItem
+	}
+
+	switch strings.ToUpper(method) {
+	case "GET":
+		pathItem.Get = operation
+	case "POST":
+		pathItem.Post = operation
+	default:
+		return fmt.Errorf("unsupported OpenAPI operation method: `%s`", method)
+	}
+
+	return nil
+}
+
+// Endpoints returns every documented operation alongside its path and HTTP method, sorted by path.
+func (d *Document) Endpoints() []Endpoint {
+	var endpoints []Endpoint
+
+	for path, pathItem := range d.Paths {
+		if pathItem.Get != nil {
+			endpoints = append(endpoints, Endpoint{Method: "GET", Path: path, Operation: pathItem.Get})
+		}
+		if pathItem.Post != nil {
+			endpoints = append(endpoints, Endpoint{Method: "POST", Path: path, Operation: pathItem.Post})
+		}
+	}
+
+	sort.Slice(endpoints, func(i, j int) bool {
+		if endpoints[i].Path == endpoints[j].Path {
+			return endpoints[i].Method < endpoints[j].Method
+		}
+		return endpoints[i].Path < endpoints[j].Path
+	})
+	return endpoints
+}
+
+// SchemaProperties flattens the top-level properties of a component schema for display.
+func (d *Document) SchemaProperties(name string) []Property {
+	schema, ok := d.Components.Schemas[name]
+	if !ok {
+		return []Property{}
+	}
+
+	required := make(map[string]bool)
+	if requiredList, ok := schema["required"].([]interface{}); ok {
+		for _, r := range requiredList {
+			required[fmt.Sprintf("%v", r)] = true
+		}
+	}
+
+	var properties []Property
+	if propertyMap, ok := asMap(schema["properties"]); ok {
+		for propertyName, rawProperty := range propertyMap {
+			property := Property{Name: propertyName, Required: required[propertyName]}
+			if p, ok := asMap(rawProperty); ok {
+				property.Type = TypeOf(p)
+				property.Description, _ = p["description"].(string)
+			}
+			properties = append(properties, property)
+		}
+	}
+
+	sort.Slice(properties, func(i, j int) bool {
+		return properties[i].Name < properties[j].Name
+	})
+	return properties
+}
+
+// SchemaNames returns the names of every component schema, sorted.
+func (d *Document) SchemaNames() []string {
+	var names []string
+	for name := range d.Components.Schemas {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+	return names
+}
+
+// TypeOf returns a short, human-readable type of a schema.
+//
+// e.g. "string", "array of integer", "Purdoobah"
+func TypeOf(schema map[string]interface{}) string {
+	if ref, ok := schema["$ref"].(string); ok {
+		return strings.TrimPrefix(ref, "#/components/schemas/")
+	}
+
+	switch t := schema["type"].(type) {
+	case string:
+		if items, ok := asMap(schema["items"]); ok && t == "array" {
+			return fmt.Sprintf("array of %s", TypeOf(items))
+		}
+		return t
+	case []interface{}:
+		var types []string
+		for _, v := range t {
+			types = append(types, fmt.Sprintf("%v", v))
+		}
+		return strings.Join(types, " | ")
+	}
+
+	return "object"
+}
+
+// Ref returns a schema which references a component schema.
+func Ref(name string) Schema {
+	return Schema{"$ref": fmt.Sprintf("#/components/schemas/%s", name)}
+}
+
+// ArrayOf returns a schema of an array whose items match the given schema.
+func ArrayOf(items Schema) Schema {
+	return Schema{"type": "array", "items": items}
+}
+
+// JSONContent returns the content map of a JSON request/response body.
+func JSONContent(schema Schema) map[string]*MediaType {
+	return map[string]*MediaType{
+		"application/json": {Schema: schema},
+	}
+}
+
+// LoadJSONSchema reads in a JSON Schema file so that it may be used as a component schema.
+//
+// The `$schema` and `$id` keywords are removed, as they aren't meaningful inside an OpenAPI document.
+func LoadJSONSchema(filepath string) (Schema, error) {
+	b, err := ioutil.ReadFile(filepath)
+	if err != nil {
+		return Schema{}, err
+	}
+
+	var schema Schema
+	err = json.Unmarshal(b, &schema)
+	if err != nil {
+		return Schema{}, err
+	}
+
+	delete(schema, "$schema")
+	delete(schema, "$id")
+
+	return schema, nil
+}
+
+// AddProperty adds a property to an object schema; useful for fields that are generated on load, not stored in assets.
+func (s Schema) AddProperty(name string, property Schema, required bool) {
+	properties, ok := asMap(s["properties"])
+	if !ok {
+		properties = make(map[string]interface{})
+		s["properties"] = properties
+	}
+	properties[name] = property
+
+	if required {
+		requiredList, _ := s["required"].([]interface{})
+		s["required"] = append(requiredList, name)
+	}
+}
+
+// asMap converts both schemas built in Go and schemas unmarshalled from JSON into a plain map.
+func asMap(v interface{}) (map[string]interface{}, bool) {
+	switch m := v.(type) {
+	case Schema:
+		return m, true
+	case map[string]interface{}:
+		return m, true
+	}
+	return nil, false
+}
@@ -0,0 +1,79 @@
+package purdoobahs
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// Honor is an achievement from the achievements registry held by a Purdoobah. In their asset file it's either `true`,
+// or the list of years they earned it, for achievements that are earned per year.
+type Honor struct {
+	Years []int
+}
+
+func (h *Honor) UnmarshalJSON(b []byte) error {
+	if bytes.Equal(bytes.TrimSpace(b), []byte("true")) {
+		h.Years = nil
+		return nil
+	}
+
+	var years []int
+	err := json.Unmarshal(b, &years)
+	if err != nil {
+		return fmt.Errorf("an honor is either `true` or a list of years: %w", err)
+	}
+	h.Years = years
+	return nil
+}
+
+func (h Honor) MarshalJSON() ([]byte, error) {
+	if len(h.Years) == 0 {
+		return []byte("true"), nil
+	}
+	return json.Marshal(h.Years)
+}
+
+// builtInAchievements are the achievements that predate the registry, and so are still stored in their own fields.
+var builtInAchievements = map[string]func(p *Purdoobah) ([]int, bool){
+	"bottom-feeder-committee": func(p *Purdoobah) ([]int, bool) {
+		return nil, p.Achievements.BottomFeederCommittee
+	},
+	"spoonsassins-victor": func(p *Purdoobah) ([]int, bool) {
+		return p.Achievements.SpoonsassinsVictories, len(p.Achievements.SpoonsassinsVictories) > 0
+	},
+	"kappa-kappa-psi": func(p *Purdoobah) ([]int, bool) {
+		return nil, p.Achievements.KappaKappaPsi
+	},
+	"tau-beta-sigma": func(p *Purdoobah) ([]int, bool) {
+		return nil, p.Achievements.TauBetaSigma
+	},
+}
+
+// BuiltInAchievements returns the IDs of the achievements stored in their own fields, sorted.
+func BuiltInAchievements() []string {
+	ids := make([]string, 0, len(builtInAchievements))
+	for id := range builtInAchievements {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// IsBuiltInAchievement returns whether the achievement is stored in its own field rather than in the honors.
+func IsBuiltInAchievement(id string) bool {
+	_, ok := builtInAchievements[id]
+	return ok
+}
+
+// Achievement returns whether this Purdoobah holds the achievement with the given ID, along with the years they earned
+// it if it's earned per year.
+func (p *Purdoobah) Achievement(id string) ([]int, bool) {
+	if builtIn, ok := builtInAchievements[id]; ok {
+		return builtIn(p)
+	}
+
+	honor, ok := p.Achievements.Honors[id]
+	return honor.Years, ok
+}
@@ -1,5 +1,15 @@
 package purdoobahs
 
+import "errors"
+
+var (
+	// ErrPurdoobahNotFound is returned when no Purdoobah exists with the requested name.
+	ErrPurdoobahNotFound = errors.New("no Purdoobah exists with that name")
+
+	// ErrSectionNotFound is returned when no Purdoobah marched during the requested year.
+	ErrSectionNotFound = errors.New("no section exists for that year")
+)
+
 // IPurdoobahService defines a Purdoobah Service
 type IPurdoobahService interface {
 	All() ([]*Purdoobah, error)
@@ -8,3 +18,23 @@ type IPurdoobahService interface {
 	SectionByYear(int) ([]*Purdoobah, error)
 	AllSectionYears() ([]int, error)
 }
+
+// CurrentAcademicYear returns the value of the current academic year.
+// e.g. if the academic year is "Fall 2020 -> Spring 2021", it will return "2020"
+func CurrentAcademicYear() int {
+	// t := time.Now()
+	// switch t.Month() {
+	// case time.January,
+	// 	time.February,
+	// 	time.March,
+	// 	time.April,
+	// 	time.May,
+	// 	time.June,
+	// 	time.July,
+	// 	time.August:
+	// 	return t.Year() - 1
+	// default:
+	// 	return t.Year()
+	// }
+	return 2020
+}
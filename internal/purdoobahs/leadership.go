@@ -0,0 +1,212 @@
+package purdoobahs
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// LeadershipRole is a leadership position within the section.
+type LeadershipRole string
+
+const (
+	SectionLeader          LeadershipRole = "section-leader"
+	AssistantSectionLeader LeadershipRole = "assistant-section-leader"
+	CommitteeChair         LeadershipRole = "committee-chair"
+)
+
+// BottomFeederCommittee is the name of the committee that has an achievement of its own.
+const BottomFeederCommittee = "Bottom Feeder Committee"
+
+// leadershipRank orders roles from the most to the least senior.
+var leadershipRank = map[LeadershipRole]int{
+	SectionLeader:          0,
+	AssistantSectionLeader: 1,
+	CommitteeChair:         2,
+}
+
+// Leadership is a role a Purdoobah held within the section, and the years they held it.
+type Leadership struct {
+	Role LeadershipRole `json:"role"`
+
+	// Committee is the committee a committee chair chaired (e.g. "Bottom Feeder Committee").
+	Committee string `json:"committee,omitempty"`
+
+	Years []int `json:"years"`
+}
+
+// Title returns the role's human-readable name.
+func (l Leadership) Title() string {
+	switch l.Role {
+	case SectionLeader:
+		return "Section Leader"
+	case AssistantSectionLeader:
+		return "Assistant Section Leader"
+	case CommitteeChair:
+		return fmt.Sprintf("%s Chair", l.Committee)
+	default:
+		return string(l.Role)
+	}
+}
+
+// IsStudentLeader returns whether the role leads the whole section, as opposed to e.g. a single committee.
+func (l Leadership) IsStudentLeader() bool {
+	return l.Role == SectionLeader || l.Role == AssistantSectionLeader
+}
+
+// Outranks returns whether the role is more senior than the other one. Committee chairs are ordered by committee.
+func (l Leadership) Outranks(other Leadership) bool {
+	if leadershipRank[l.Role] != leadershipRank[other.Role] {
+		return leadershipRank[l.Role] < leadershipRank[other.Role]
+	}
+	return l.Committee < other.Committee
+}
+
+// Terms returns the role's consecutive runs of years, earliest first.
+func (l Leadership) Terms() []LeadershipTerm {
+	years := make([]int, len(l.Years))
+	copy(years, l.Years)
+	sort.Ints(years)
+
+	terms := make([]LeadershipTerm, 0)
+	for _, year := range years {
+		if n := len(terms); n > 0 && terms[n-1].End+1 == year {
+			terms[n-1].End = year
+			continue
+		}
+		terms = append(terms, LeadershipTerm{
+			Role:      l.Role,
+			Committee: l.Committee,
+			Title:     l.Title(),
+			Start:     year,
+			End:       year,
+		})
+	}
+	return terms
+}
+
+// LeadershipTerm is a consecutive run of years a Purdoobah held a role.
+type LeadershipTerm struct {
+	Role      LeadershipRole `json:"role"`
+	Committee string         `json:"committee,omitempty"`
+	Title     string         `json:"title"`
+	Start     int            `json:"start"`
+	End       int            `json:"end"`
+}
+
+// String formats the term's years, e.g. "2017–2018".
+func (t LeadershipTerm) String() string {
+	if t.Start == t.End {
+		return fmt.Sprintf("%d", t.Start)
+	}
+	return fmt.Sprintf("%d–%d", t.Start, t.End)
+}
+
+// ByLeadership sorts leadership roles from the most to the least senior, then by committee
+type ByLeadership []Leadership
+
+func (l ByLeadership) Len() int {
+	return len(l)
+}
+
+func (l ByLeadership) Swap(i, j int) {
+	l[i], l[j] = l[j], l[i]
+}
+
+func (l ByLeadership) Less(i, j int) bool {
+	return l[i].Outranks(l[j])
+}
+
+// IsStudentLeader returns whether this Purdoobah ever led the section.
+func (p *Purdoobah) IsStudentLeader() bool {
+	return len(p.StudentLeaderYears()) > 0
+}
+
+// IsStudentLeaderInYear returns whether this Purdoobah led the section during the given year.
+func (p *Purdoobah) IsStudentLeaderInYear(targetYear int) bool {
+	for _, l := range p.LeadershipInYear(targetYear) {
+		if l.IsStudentLeader() {
+			return true
+		}
+	}
+	return false
+}
+
+// StudentLeaderYears returns the years this Purdoobah led the section, earliest first.
+func (p *Purdoobah) StudentLeaderYears() []int {
+	unique := make(map[int]bool)
+	for _, l := range p.Achievements.Leadership {
+		if l.IsStudentLeader() {
+			for _, year := range l.Years {
+				unique[year] = true
+			}
+		}
+	}
+
+	years := make([]int, 0, len(unique))
+	for year := range unique {
+		years = append(years, year)
+	}
+	sort.Ints(years)
+	return years
+}
+
+// ChairedCommittee returns whether this Purdoobah ever chaired the given committee.
+func (p *Purdoobah) ChairedCommittee(committee string) bool {
+	for _, l := range p.Achievements.Leadership {
+		if l.Role == CommitteeChair && strings.EqualFold(l.Committee, committee) {
+			return true
+		}
+	}
+	return false
+}
+
+// LeadershipInYear returns the roles this Purdoobah held during the given year, the most senior first.
+func (p *Purdoobah) LeadershipInYear(targetYear int) []Leadership {
+	roles := make([]Leadership, 0)
+	for _, l := range p.Achievements.Leadership {
+		for _, year := range l.Years {
+			if year == targetYear {
+				roles = append(roles, l)
+				break
+			}
+		}
+	}
+	sort.Stable(ByLeadership(roles))
+	return roles
+}
+
+// LeadershipTimeline returns every term this Purdoobah held a role, earliest first.
+func (p *Purdoobah) LeadershipTimeline() []LeadershipTerm {
+	timeline := make([]LeadershipTerm, 0)
+	for _, l := range p.Achievements.Leadership {
+		timeline = append(timeline, l.Terms()...)
+	}
+
+	sort.SliceStable(timeline, func(i, j int) bool {
+		if timeline[i].Start != timeline[j].Start {
+			return timeline[i].Start < timeline[j].Start
+		}
+		return leadershipRank[timeline[i].Role] < leadershipRank[timeline[j].Role]
+	})
+	return timeline
+}
+
+// ValidateLeadership makes sure every role was held during a year this Purdoobah marched, and that every committee
+// chair names their committee.
+func (p *Purdoobah) ValidateLeadership() error {
+	for _, l := range p.Achievements.Leadership {
+		if _, ok := leadershipRank[l.Role]; !ok {
+			return fmt.Errorf("Purdoobah `%s` has an unknown leadership role: `%s`", p.ID, l.Role)
+		}
+		if l.Role == CommitteeChair && l.Committee == "" {
+			return fmt.Errorf("Purdoobah `%s` chaired a committee without naming it", p.ID)
+		}
+		for _, year := range l.Years {
+			if !p.MarchedDuringYear(year) || year == -1 {
+				return fmt.Errorf("Purdoobah `%s` was a %s during a year they didn't march: `%d`", p.ID, l.Title(), year)
+			}
+		}
+	}
+	return nil
+}
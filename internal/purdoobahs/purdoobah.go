@@ -1,6 +1,10 @@
 package purdoobahs
 
-import "strings"
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
 
 // Year defines a Purdoobahs year in school
 type Year string
@@ -14,6 +18,35 @@ const (
 	Freshman         = "freshman"
 )
 
+// Season is what was true of a Purdoobah during a single season.
+//
+// In a Purdoobah's asset file, every field but the year is optional and overrides what would otherwise be assumed
+// from their current details.
+type Season struct {
+	Year          int    `json:"year"`
+	ClassStanding Year   `json:"class_standing,omitempty"`
+	Major         string `json:"major,omitempty"`
+	Minor         string `json:"minor,omitempty"`
+	Role          string `json:"role,omitempty"`
+}
+
+// Location is a city and state (or province/country, outside of the US).
+type Location struct {
+	City  string `json:"city,omitempty"`
+	State string `json:"state,omitempty"`
+}
+
+// Position is a single job in an alumnus' career.
+type Position struct {
+	Employer string `json:"employer"`
+	Title    string `json:"title"`
+	Industry string `json:"industry,omitempty"`
+	Start    int    `json:"start"`
+
+	// End is unset for a current position.
+	End int `json:"end,omitempty"`
+}
+
 // ByName sorts Purdoobahs by name
 type ByName []*Purdoobah
 
@@ -40,14 +73,18 @@ type Purdoobah struct {
 	Emoji string `json:"emoji"`
 
 	Marching struct {
-		YearsMarched []int  `json:"years_marched"`
-		Shoutout     string `json:"shoutout,omitempty"`
+		YearsMarched []int    `json:"years_marched"`
+		Shoutout     string   `json:"shoutout,omitempty"`
+		Seasons      []Season `json:"seasons,omitempty"`
 	} `json:"marching"`
 
 	Education struct {
 		Major string `json:"major"`
 		Minor string `json:"minor,omitempty"`
-		Year  Year   `json:"year"`
+		Year  Year   `json:"year,omitempty"`
+
+		// EffectiveYear is generated on load from the years marched (see DerivedYear).
+		EffectiveYear Year `json:"effective_year"`
 	} `json:"education"`
 
 	Hometown struct {
@@ -56,12 +93,25 @@ type Purdoobah struct {
 	} `json:"hometown"`
 
 	Alumni struct {
-		Job string `json:"job,omitempty"`
+		Job            string     `json:"job,omitempty"`
+		Employer       string     `json:"employer,omitempty"`
+		Title          string     `json:"title,omitempty"`
+		Industry       string     `json:"industry,omitempty"`
+		Location       Location   `json:"location,omitempty"`
+		GraduationYear int        `json:"graduation_year,omitempty"`
+		Positions      []Position `json:"positions,omitempty"`
+
+		// Visibility is what this Purdoobah has agreed to share; everything else is redacted on load (see RedactAlumni).
+		Visibility struct {
+			Career   bool `json:"career,omitempty"`
+			Location bool `json:"location,omitempty"`
+		} `json:"visibility,omitempty"`
 	} `json:"alumni,omitempty"`
 
 	Personal struct {
-		Hobbies []string `json:"hobbies,omitempty"`
-		Socials struct {
+		Hobbies      []string `json:"hobbies,omitempty"`
+		ShareContact bool     `json:"share_contact,omitempty"`
+		Socials      struct {
 			Facebook  string `json:"facebook,omitempty"`
 			Instagram string `json:"instagram,omitempty"`
 			LinkedIn  string `json:"linkedin,omitempty"`
@@ -69,19 +119,57 @@ type Purdoobah struct {
 	} `json:"personal,omitempty"`
 
 	Achievements struct {
-		StudentLeader         []int `json:"student_leader,omitempty"`
+		Leadership []Leadership `json:"leadership,omitempty"`
+
+		// StudentLeader is generated on load from the leadership roles (see StudentLeaderYears); it's only kept so that
+		// API clients written before roles were modeled keep working.
+		StudentLeader []int `json:"student_leader,omitempty"`
+
+		// BottomFeederCommittee is whether this Purdoobah was a member of the committee. Chairing it is a leadership
+		// role, and chairs are marked as members on load (see ChairedCommittee).
 		BottomFeederCommittee bool  `json:"bottom_feeder_committee,omitempty"`
 		SpoonsassinsVictories []int `json:"spoonsassins_victories,omitempty"`
 		KappaKappaPsi         bool  `json:"kappa_kappa_psi,omitempty"`
 		TauBetaSigma          bool  `json:"tau_beta_sigma,omitempty"`
+
+		// Honors are the achievements from the achievements registry that don't have a field of their own, by ID.
+		Honors map[string]Honor `json:"honors,omitempty"`
 	} `json:"achievements,omitempty"`
 
 	Metadata struct {
 		Image struct {
 			File string `json:"file"`
 			Alt  string `json:"alt"`
+
+			// Width and Height are the size of the image in pixels, so that room can be made for it before it loads.
+			Width  int `json:"width"`
+			Height int `json:"height"`
+
+			// DominantColor is the most common color of the image, as a hex code.
+			DominantColor string `json:"dominant_color"`
+
+			// Placeholder is a tiny version of the image, as a data URL, to show blurred while the image loads. It's left
+			// out of lists, where it would add up.
+			Placeholder string `json:"placeholder,omitempty"`
 		} `json:"image"`
 	} `json:"metadata"`
+
+	// Season is set when this record describes the Purdoobah as they were during a single season (see AsOfSeason).
+	Season *Season `json:"season,omitempty"`
+
+	// current is the Purdoobah as they are today, when this record describes them as of a past season
+	current *Purdoobah
+}
+
+// FullName is the Purdoobah's real name, in the order it would be spoken.
+func (p *Purdoobah) FullName() string {
+	parts := make([]string, 0, 3)
+	for _, part := range []string{p.BirthCertificateName.First, p.BirthCertificateName.Middle, p.BirthCertificateName.Last} {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, " ")
 }
 
 func (p *Purdoobah) MarchedDuringYear(targetYear int) bool {
@@ -93,19 +181,264 @@ func (p *Purdoobah) MarchedDuringYear(targetYear int) bool {
 	return false
 }
 
-func (p *Purdoobah) IsStudentLeader() bool {
-	return len(p.Achievements.StudentLeader) > 0
+// HasKnownHistory returns whether we know which years this Purdoobah marched.
+func (p *Purdoobah) HasKnownHistory() bool {
+	return !p.MarchedDuringYear(-1)
 }
 
-func (p *Purdoobah) IsStudentLeaderInYear(targetYear int) bool {
-	for _, year := range p.Achievements.StudentLeader {
+// SeasonNumber returns which of this Purdoobah's seasons the given year was (1 for their first season), or 0 if they
+// didn't march that year.
+func (p *Purdoobah) SeasonNumber(targetYear int) int {
+	seasonNumber := 0
+	marched := false
+	for _, year := range p.Marching.YearsMarched {
+		if year <= targetYear && year != -1 {
+			seasonNumber++
+		}
 		if year == targetYear {
+			marched = true
+		}
+	}
+
+	if !marched || targetYear == -1 {
+		return 0
+	}
+	return seasonNumber
+}
+
+// ClassStandingInYear returns this Purdoobah's year in school during the given year.
+//
+// A class standing recorded for that season wins. Otherwise, the current season uses their current year in school,
+// and any other season derives it from how many seasons they had marched by then, assuming they marched every season
+// since they were a freshman.
+//
+// Returns an empty Year if they didn't march that year.
+func (p *Purdoobah) ClassStandingInYear(targetYear int) Year {
+	p = p.Current()
+	if !p.MarchedDuringYear(targetYear) || targetYear == -1 {
+		return ""
+	}
+
+	if season, ok := p.recordedSeason(targetYear); ok && season.ClassStanding != "" {
+		return season.ClassStanding
+	}
+
+	if targetYear == CurrentAcademicYear() && p.Education.Year != "" && p.Education.Year != Alumni {
+		return p.Education.Year
+	}
+
+	switch p.SeasonNumber(targetYear) {
+	case 1:
+		return Freshman
+	case 2:
+		return Sophomore
+	case 3:
+		return Junior
+	case 4:
+		return Senior
+	default:
+		return SuperSenior
+	}
+}
+
+// DerivedYear computes this Purdoobah's year in school today from the years they marched: anyone marching this
+// academic year is a student (see ClassStandingInYear, which honors a stored year in school as an override), and
+// anyone else is an alumnus.
+//
+// The stored year in school is used as-is when their marching history is unknown, and alumni is assumed if there
+// isn't one.
+func (p *Purdoobah) DerivedYear() Year {
+	p = p.Current()
+	if !p.HasKnownHistory() {
+		if p.Education.Year == "" {
+			return Alumni
+		}
+		return p.Education.Year
+	}
+
+	currentAcademicYear := CurrentAcademicYear()
+	if p.MarchedDuringYear(currentAcademicYear) {
+		return p.ClassStandingInYear(currentAcademicYear)
+	}
+	return Alumni
+}
+
+// AsOfSeason returns a copy of this Purdoobah as they were during the given season: their class standing, major,
+// minor, and role that season replace their current ones, and are also summarized in the copy's Season.
+//
+// Returns the Purdoobah itself if they didn't march that year, or if their marching history is unknown.
+func (p *Purdoobah) AsOfSeason(targetYear int) *Purdoobah {
+	p = p.Current()
+	if !p.MarchedDuringYear(targetYear) || targetYear == -1 {
+		return p
+	}
+
+	season := &Season{
+		Year:          targetYear,
+		ClassStanding: p.ClassStandingInYear(targetYear),
+		Major:         p.Education.Major,
+		Minor:         p.Education.Minor,
+	}
+	if roles := p.LeadershipInYear(targetYear); len(roles) > 0 {
+		season.Role = roles[0].Title()
+	}
+	if recorded, ok := p.recordedSeason(targetYear); ok {
+		if recorded.Major != "" {
+			season.Major = recorded.Major
+		}
+		if recorded.Minor != "" {
+			season.Minor = recorded.Minor
+		}
+		if recorded.Role != "" {
+			season.Role = recorded.Role
+		}
+	}
+
+	snapshot := *p
+	snapshot.Education.Year = season.ClassStanding
+	snapshot.Education.EffectiveYear = season.ClassStanding
+	snapshot.Education.Major = season.Major
+	snapshot.Education.Minor = season.Minor
+	snapshot.Season = season
+	snapshot.current = p
+	return &snapshot
+}
+
+// Current returns this Purdoobah as they are today, undoing AsOfSeason.
+func (p *Purdoobah) Current() *Purdoobah {
+	if p.current != nil {
+		return p.current
+	}
+	return p
+}
+
+// WithSpoonsassinsVictories returns a copy of this Purdoobah who also won the Spoonsassins in the given seasons (e.g.
+// ones won in a Toobahsassins game but not yet added to their asset file). The Purdoobah is returned as is if they
+// already had all of them.
+func (p *Purdoobah) WithSpoonsassinsVictories(seasons []int) *Purdoobah {
+	if len(seasons) == 0 {
+		return p
+	}
+
+	victories := make([]int, 0, len(p.Achievements.SpoonsassinsVictories)+len(seasons))
+	victories = append(victories, p.Achievements.SpoonsassinsVictories...)
+	for _, season := range seasons {
+		if !containsInt(victories, season) {
+			victories = append(victories, season)
+		}
+	}
+	if len(victories) == len(p.Achievements.SpoonsassinsVictories) {
+		return p
+	}
+	sort.Ints(victories)
+
+	c := *p
+	c.Achievements.SpoonsassinsVictories = victories
+	if p.current != nil {
+		c.current = p.current.WithSpoonsassinsVictories(seasons)
+	}
+	return &c
+}
+
+// WithoutPlaceholder returns a copy of this Purdoobah without its image placeholder.
+func (p *Purdoobah) WithoutPlaceholder() *Purdoobah {
+	c := *p
+	c.Metadata.Image.Placeholder = ""
+	return &c
+}
+
+func containsInt(s []int, target int) bool {
+	for _, v := range s {
+		if v == target {
 			return true
 		}
 	}
 	return false
 }
 
+// ValidateSeasons makes sure every recorded season is a year this Purdoobah marched, and is only recorded once.
+func (p *Purdoobah) ValidateSeasons() error {
+	recorded := make(map[int]bool)
+	for _, season := range p.Marching.Seasons {
+		if !p.MarchedDuringYear(season.Year) || season.Year == -1 {
+			return fmt.Errorf("Purdoobah `%s` has a season for a year they didn't march: `%d`", p.ID, season.Year)
+		}
+		if recorded[season.Year] {
+			return fmt.Errorf("Purdoobah `%s` has more than one season for the same year: `%d`", p.ID, season.Year)
+		}
+		recorded[season.Year] = true
+	}
+	return nil
+}
+
+// recordedSeason returns the season recorded in this Purdoobah's asset file for the given year, if there is one.
+func (p *Purdoobah) recordedSeason(targetYear int) (Season, bool) {
+	for _, season := range p.Marching.Seasons {
+		if season.Year == targetYear {
+			return season, true
+		}
+	}
+	return Season{}, false
+}
+
+// RedactAlumni clears every part of this Purdoobah's post-grad life they haven't agreed to share: their career
+// (employer, title, industry, and positions) and their current location.
+//
+// Their free-form job and graduation year were always public, and remain so.
+func (p *Purdoobah) RedactAlumni() {
+	if !p.Alumni.Visibility.Career {
+		p.Alumni.Employer = ""
+		p.Alumni.Title = ""
+		p.Alumni.Industry = ""
+		p.Alumni.Positions = nil
+	}
+	if !p.Alumni.Visibility.Location {
+		p.Alumni.Location = Location{}
+	}
+}
+
+// JobTitle describes this Purdoobah's current job, e.g. "Engineer at Purdue", falling back to their free-form job.
+func (p *Purdoobah) JobTitle() string {
+	switch {
+	case p.Alumni.Title != "" && p.Alumni.Employer != "":
+		return fmt.Sprintf("%s at %s", p.Alumni.Title, p.Alumni.Employer)
+	case p.Alumni.Title != "":
+		return p.Alumni.Title
+	case p.Alumni.Employer != "":
+		return p.Alumni.Employer
+	default:
+		return p.Alumni.Job
+	}
+}
+
+// GraduationYear returns the year this Purdoobah graduated (or will graduate), and whether it was estimated.
+//
+// Unless it's recorded, it's estimated as the spring after their senior season, assuming they marched every season
+// since they were a freshman. Returns 0 if their marching history is unknown.
+func (p *Purdoobah) GraduationYear() (int, bool) {
+	p = p.Current()
+	if p.Alumni.GraduationYear != 0 {
+		return p.Alumni.GraduationYear, false
+	}
+	if !p.HasKnownHistory() {
+		return 0, true
+	}
+
+	lastSeason := -1
+	for _, year := range p.Marching.YearsMarched {
+		if year > lastSeason {
+			lastSeason = year
+		}
+	}
+
+	remainingSeasons := 4 - p.SeasonNumber(lastSeason)
+	if remainingSeasons < 0 {
+		remainingSeasons = 0
+	}
+	return lastSeason + remainingSeasons + 1, true
+}
+
+// IsYear compares against this Purdoobah's effective year in school.
 func (p *Purdoobah) IsYear(targetYear Year) bool {
-	return p.Education.Year == targetYear
+	return p.Education.EffectiveYear == targetYear
 }
@@ -0,0 +1,47 @@
+package ratelimit
+
+import (
+	"math"
+	"time"
+)
+
+// bucket is a token bucket which holds at most `capacity` tokens and refills at `refillRate` tokens per second.
+type bucket struct {
+	key        string
+	tokens     float64
+	lastRefill time.Time
+}
+
+// take refills the bucket and attempts to remove a single token from it.
+func (b *bucket) take(now time.Time, capacity int, refillRate float64) bool {
+	elapsed := now.Sub(b.lastRefill).Seconds()
+	if elapsed > 0 {
+		b.tokens = math.Min(float64(capacity), b.tokens+(elapsed*refillRate))
+		b.lastRefill = now
+	}
+
+	if b.tokens < 1 {
+		return false
+	}
+
+	b.tokens--
+	return true
+}
+
+// remaining returns the number of whole tokens left in the bucket.
+func (b *bucket) remaining() int {
+	return int(math.Floor(b.tokens))
+}
+
+// untilNextToken returns how long until the bucket holds at least one token.
+func (b *bucket) untilNextToken(refillRate float64) time.Duration {
+	if b.tokens >= 1 {
+		return 0
+	}
+	return time.Duration(((1 - b.tokens) / refillRate) * float64(time.Second))
+}
+
+// untilFull returns how long until the bucket is completely refilled.
+func (b *bucket) untilFull(capacity int, refillRate float64) time.Duration {
+	return time.Duration(((float64(capacity) - b.tokens) / refillRate) * float64(time.Second))
+}
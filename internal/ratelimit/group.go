@@ -0,0 +1,102 @@
+package ratelimit
+
+import (
+	"container/list"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Group is a set of routes which share the same rate limit.
+type Group struct {
+	// Name identifies this group in log lines.
+	Name string
+
+	// RoutePrefix is the route prefix this group applies to.
+	RoutePrefix string
+
+	// Match narrows the group down to some of the requests under its route prefix (e.g. only form submissions); nil
+	// matches all of them.
+	Match func(r *http.Request) bool
+
+	// Requests is the amount of requests a single client is allowed to burst within Per.
+	Requests int
+
+	// Per is the window in which Requests are replenished.
+	Per time.Duration
+
+	// MaxBuckets bounds the memory used by this group; the least recently used bucket is evicted once it is reached.
+	MaxBuckets int
+
+	mu      sync.Mutex
+	buckets map[string]*list.Element
+	lru     *list.List
+}
+
+// result is the outcome of a single request against a Group.
+type result struct {
+	allowed    bool
+	remaining  int
+	reset      time.Duration
+	retryAfter time.Duration
+}
+
+func NewGroup(name, routePrefix string, requests int, per time.Duration, maxBuckets int) *Group {
+	return &Group{
+		Name:        name,
+		RoutePrefix: routePrefix,
+		Requests:    requests,
+		Per:         per,
+		MaxBuckets:  maxBuckets,
+		buckets:     make(map[string]*list.Element),
+		lru:         list.New(),
+	}
+}
+
+// refillRate returns how many tokens are replenished per second.
+func (g *Group) refillRate() float64 {
+	return float64(g.Requests) / g.Per.Seconds()
+}
+
+// take removes a token from the given client's bucket.
+func (g *Group) take(key string, now time.Time) result {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	b := g.bucket(key, now)
+	allowed := b.take(now, g.Requests, g.refillRate())
+
+	return result{
+		allowed:    allowed,
+		remaining:  b.remaining(),
+		reset:      b.untilFull(g.Requests, g.refillRate()),
+		retryAfter: b.untilNextToken(g.refillRate()),
+	}
+}
+
+// bucket returns the bucket of the given client, creating it if it doesn't exist yet.
+//
+// The caller must hold the lock.
+func (g *Group) bucket(key string, now time.Time) *bucket {
+	if element, ok := g.buckets[key]; ok {
+		g.lru.MoveToFront(element)
+		return element.Value.(*bucket)
+	}
+
+	// evict the least recently used buckets to make room
+	// (an evicted bucket would have refilled to capacity by the time it is recreated in all but the busiest cases)
+	for g.MaxBuckets > 0 && g.lru.Len() >= g.MaxBuckets {
+		oldest := g.lru.Back()
+		g.lru.Remove(oldest)
+		delete(g.buckets, oldest.Value.(*bucket).key)
+	}
+
+	b := &bucket{
+		key:        key,
+		tokens:     float64(g.Requests),
+		lastRefill: now,
+	}
+	g.buckets[key] = g.lru.PushFront(b)
+
+	return b
+}
@@ -0,0 +1,106 @@
+package ratelimit
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/purdoobahs/purdoobahs.com/internal/httpheader"
+	"github.com/purdoobahs/purdoobahs.com/internal/mimetype"
+)
+
+type RateLimiter struct {
+	// Debug toggles debug log lines.
+	Debug bool
+
+	// KeyFunc identifies the client a request belongs to.
+	KeyFunc func(r *http.Request) string
+
+	// ExceededHandler writes the response for a request that has exceeded its rate limit.
+	ExceededHandler http.Handler
+
+	// groups is the list of rate limited route groups; the group with the longest matching route prefix wins.
+	groups []*Group
+}
+
+func NewRateLimiter(keyFunc func(r *http.Request) string) *RateLimiter {
+	return &RateLimiter{
+		Debug:           false,
+		KeyFunc:         keyFunc,
+		ExceededHandler: http.HandlerFunc(tooManyRequests),
+		groups:          []*Group{},
+	}
+}
+
+// AddGroup rate limits every route beginning with the group's route prefix (that the group matches).
+func (rl *RateLimiter) AddGroup(group *Group) {
+	rl.groups = append(rl.groups, group)
+}
+
+// Limit is an HTTP server middleware which rate limits clients per route group using a token bucket.
+//
+// Every rate limited response carries the RateLimit-* headers; rejected requests also carry Retry-After.
+func (rl *RateLimiter) Limit(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		group := rl.match(r)
+		if group == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		key := rl.KeyFunc(r)
+		res := group.take(key, time.Now())
+
+		w.Header().Set(httpheader.ExperimentalRateLimitLimit.String(), strconv.Itoa(group.Requests))
+		w.Header().Set(
+			httpheader.ExperimentalRateLimitPolicy.String(),
+			fmt.Sprintf("%d;w=%.0f", group.Requests, group.Per.Seconds()),
+		)
+		w.Header().Set(httpheader.ExperimentalRateLimitRemaining.String(), strconv.Itoa(res.remaining))
+		w.Header().Set(httpheader.ExperimentalRateLimitReset.String(), ceilSeconds(res.reset))
+
+		if !res.allowed {
+			if rl.Debug {
+				fmt.Printf("Rate limited (%s): `%s` `%s`\n", group.Name, key, r.URL.RequestURI())
+			}
+
+			w.Header().Set(httpheader.RetryAfter.String(), ceilSeconds(res.retryAfter))
+			rl.ExceededHandler.ServeHTTP(w, r)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// match returns the group with the longest route prefix matching the request, or nil if there isn't one.
+func (rl *RateLimiter) match(r *http.Request) *Group {
+	var longest *Group
+	for _, group := range rl.groups {
+		if !strings.HasPrefix(r.URL.Path, group.RoutePrefix) || (group.Match != nil && !group.Match(r)) {
+			continue
+		}
+		if longest == nil || len(group.RoutePrefix) > len(longest.RoutePrefix) {
+			longest = group
+		}
+	}
+	return longest
+}
+
+// tooManyRequests is the default ExceededHandler.
+func tooManyRequests(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set(
+		httpheader.ContentType.String(),
+		fmt.Sprintf("%s; charset=utf-8", mimetype.Json.String()),
+	)
+	w.WriteHeader(http.StatusTooManyRequests)
+	_, _ = w.Write([]byte("{ \"status\": \"error\"}"))
+}
+
+// ceilSeconds formats a duration as a whole number of seconds, rounded up.
+func ceilSeconds(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(d.Seconds())))
+}
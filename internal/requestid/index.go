@@ -0,0 +1,44 @@
+package requestid
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+
+	"github.com/purdoobahs/purdoobahs.com/internal/httpheader"
+)
+
+// contextKey is unexported to prevent collisions with context keys defined in other packages.
+type contextKey string
+
+const requestIDContextKey = contextKey("request-id")
+
+// RequestID is an HTTP server middleware which tags every request with a unique ID, stores it in the request context,
+// and echoes it back in the X-Request-ID response header.
+func RequestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := generate()
+		w.Header().Set(httpheader.NonstandardXRequestId.String(), id)
+
+		ctx := context.WithValue(r.Context(), requestIDContextKey, id)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// Get returns the ID of the request as set by the RequestID middleware, or an empty string if it didn't run.
+func Get(r *http.Request) string {
+	if id, ok := r.Context().Value(requestIDContextKey).(string); ok {
+		return id
+	}
+	return ""
+}
+
+// generate returns 16 random bytes, hex encoded.
+func generate() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return ""
+	}
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,19 @@
+package rsvp
+
+import "errors"
+
+var (
+	// ErrRSVPNotFound is returned when no RSVP exists with the requested ID.
+	ErrRSVPNotFound = errors.New("no RSVP exists with that ID")
+
+	// ErrAlreadyRSVPed is returned when the same person RSVPs to the same event twice.
+	ErrAlreadyRSVPed = errors.New("they've already RSVPed to this event")
+)
+
+// IRSVPService defines an Event RSVP Service
+type IRSVPService interface {
+	ByEvent(eventID string) ([]*RSVP, error)
+	Submit(r *RSVP, capacity int) (*RSVP, error)
+	Cancel(eventID, id string, capacity int) ([]*RSVP, error)
+	Settle(eventID string, capacity int) ([]*RSVP, error)
+}
@@ -0,0 +1,166 @@
+package rsvp
+
+import (
+	"fmt"
+	"net/mail"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Status is whether an RSVP has a seat at the event.
+type Status string
+
+const (
+	Attending  Status = "attending"
+	Waitlisted Status = "waitlisted"
+)
+
+// MaxGuests is the most guests a single RSVP can bring along.
+const MaxGuests = 10
+
+// maxNameLength keeps a single RSVP from filling up the attendee list.
+const maxNameLength = 100
+
+// RSVP is somebody saying they'll come to an event, submitted through the website.
+type RSVP struct {
+	ID          string    `json:"id"`
+	Event       string    `json:"event"`
+	SubmittedAt time.Time `json:"submitted_at"`
+
+	// who's coming
+	Name      string `json:"name"`
+	Purdoobah string `json:"purdoobah,omitempty"`
+	Email     string `json:"email,omitempty"`
+
+	// Guests is how many people they're bringing along, not counting themselves.
+	Guests int `json:"guests"`
+
+	// Status is whether they have a seat. It's decided when they RSVP (see Seat), and only ever changes from waitlisted
+	// to attending, so that nobody loses a seat they were told they have.
+	Status Status `json:"status,omitempty"`
+}
+
+// PartySize is how many seats the RSVP takes up.
+func (r *RSVP) PartySize() int {
+	return 1 + r.Guests
+}
+
+// IsSamePerson returns whether both RSVPs are from the same person, going by their Purdoobah or their name.
+func (r *RSVP) IsSamePerson(other *RSVP) bool {
+	if r.Purdoobah != "" && r.Purdoobah == other.Purdoobah {
+		return true
+	}
+	return strings.EqualFold(r.Name, other.Name)
+}
+
+// Validate trims the RSVP's fields, and returns a message for every field that needs fixing, by field name.
+func (r *RSVP) Validate() map[string]string {
+	r.Name = strings.TrimSpace(r.Name)
+	r.Purdoobah = strings.TrimSpace(r.Purdoobah)
+	r.Email = strings.TrimSpace(r.Email)
+
+	problems := make(map[string]string)
+	if r.Name == "" {
+		problems["name"] = "Please tell us who you are."
+	} else if utf8.RuneCountInString(r.Name) > maxNameLength {
+		problems["name"] = fmt.Sprintf("Please keep it under %d characters.", maxNameLength)
+	}
+	if r.Email != "" {
+		if _, err := mail.ParseAddress(r.Email); err != nil {
+			problems["email"] = "Please enter a valid email address, or leave it empty."
+		}
+	}
+	if r.Guests < 0 || r.Guests > MaxGuests {
+		problems["guests"] = fmt.Sprintf("You can bring between 0 and %d guests.", MaxGuests)
+	}
+
+	return problems
+}
+
+// Entry is an RSVP along with whether it has a seat.
+type Entry struct {
+	RSVP   *RSVP
+	Status Status
+
+	// Position is the RSVP's place in line on the waitlist, starting at 1; it's 0 for attendees.
+	Position int
+}
+
+// Summary counts the seats of an event.
+type Summary struct {
+	// Capacity is how many people can attend; 0 means there's no limit.
+	Capacity int
+
+	// Attending and Waitlisted count people, guests included.
+	Attending  int
+	Waitlisted int
+
+	// SeatsLeft is 0 when there's no limit.
+	SeatsLeft int
+}
+
+// IsFull returns whether anybody else who RSVPs is waitlisted: there's no seat left, or there's already a line.
+func (s *Summary) IsFull() bool {
+	return s.Capacity > 0 && (s.SeatsLeft == 0 || s.Waitlisted > 0)
+}
+
+// Seat hands out the seats left at an event to the RSVPs that don't have one yet, in the order they were submitted,
+// returning the RSVPs whose status changed. The waitlist is first come, first served: once a party doesn't fit, every
+// party after it waits too, so that a smaller party never jumps ahead of a larger one. Attendees keep their seats, even
+// if the event has since gotten smaller.
+func Seat(rsvps []*RSVP, capacity int) []*RSVP {
+	attending := 0
+	for _, r := range rsvps {
+		if r.Status == Attending {
+			attending += r.PartySize()
+		}
+	}
+
+	changed := make([]*RSVP, 0)
+	waiting := false
+	for _, r := range rsvps {
+		if r.Status == Attending {
+			continue
+		}
+
+		status := Waitlisted
+		if !waiting && (capacity <= 0 || attending+r.PartySize() <= capacity) {
+			status = Attending
+			attending += r.PartySize()
+		} else {
+			waiting = true
+		}
+
+		if r.Status != status {
+			r.Status = status
+			changed = append(changed, r)
+		}
+	}
+	return changed
+}
+
+// Assign lines up the RSVPs as they were seated (see Seat), in the order they were submitted, and counts the seats.
+func Assign(rsvps []*RSVP, capacity int) ([]*Entry, *Summary) {
+	entries := make([]*Entry, 0, len(rsvps))
+	summary := &Summary{Capacity: capacity}
+
+	waitlist := 0
+	for _, r := range rsvps {
+		entry := &Entry{RSVP: r, Status: r.Status}
+		if r.Status == Attending {
+			summary.Attending += r.PartySize()
+		} else {
+			waitlist++
+			summary.Waitlisted += r.PartySize()
+			entry.Status = Waitlisted
+			entry.Position = waitlist
+		}
+		entries = append(entries, entry)
+	}
+
+	if capacity > 0 && summary.Attending < capacity {
+		summary.SeatsLeft = capacity - summary.Attending
+	}
+	return entries, summary
+}
@@ -0,0 +1,11 @@
+package sectionphotos
+
+import "errors"
+
+// ErrSectionPhotoNotFound is returned when a section's photo hasn't been tagged.
+var ErrSectionPhotoNotFound = errors.New("no tagged Section Photo exists for that year")
+
+// ISectionPhotoService defines a Section Photo Service
+type ISectionPhotoService interface {
+	ByYear(int) (*SectionPhoto, error)
+}
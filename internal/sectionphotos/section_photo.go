@@ -0,0 +1,46 @@
+package sectionphotos
+
+import (
+	"fmt"
+)
+
+// SectionPhoto is who's who in the photo of a section (/static/image/section/{year}.webp).
+type SectionPhoto struct {
+	Year int `json:"year"`
+
+	// Tags are in the order they're read out by screen readers, so they're best listed left to right.
+	Tags []*Tag `json:"tags"`
+
+	// Width and Height are the photo's, in pixels; they're read from the photo on load.
+	Width  int `json:"width"`
+	Height int `json:"height"`
+}
+
+// Tag is the region of the photo a Purdoobah is in, in pixels from the top left corner of the photo.
+type Tag struct {
+	Purdoobah string `json:"purdoobah"`
+	X         int    `json:"x"`
+	Y         int    `json:"y"`
+	Width     int    `json:"width"`
+	Height    int    `json:"height"`
+}
+
+// Validate makes sure that every tag is inside the photo, and that nobody is tagged twice.
+func (sp *SectionPhoto) Validate() error {
+	tagged := make(map[string]bool)
+	for _, t := range sp.Tags {
+		if tagged[t.Purdoobah] {
+			return fmt.Errorf("%d section photo tags Purdoobah `%s` more than once", sp.Year, t.Purdoobah)
+		}
+		tagged[t.Purdoobah] = true
+
+		if t.Width < 1 || t.Height < 1 || t.X < 0 || t.Y < 0 || t.X+t.Width > sp.Width || t.Y+t.Height > sp.Height {
+			return fmt.Errorf(
+				"%d section photo tags Purdoobah `%s` outside of the %dx%d photo",
+				sp.Year, t.Purdoobah, sp.Width, sp.Height,
+			)
+		}
+	}
+
+	return nil
+}
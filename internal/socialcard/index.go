@@ -0,0 +1,322 @@
+package socialcard
+
+import (
+	"crypto/md5"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/purdoobahs/purdoobahs.com/internal/imaging"
+	"github.com/purdoobahs/purdoobahs.com/internal/keylock"
+
+	"github.com/go-fonts/liberation/liberationserifbold"
+	"github.com/go-fonts/liberation/liberationserifregular"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/font/sfnt"
+	"golang.org/x/image/math/fixed"
+)
+
+const (
+	// Width and Height are the size of every card, which is what Open Graph and Twitter recommend for large images.
+	Width  = 1200
+	Height = 630
+
+	// version is part of every card's hash, so that changing how cards are drawn replaces the cached ones.
+	version = 3
+
+	// quality is the quality (1-100) cards are encoded with, as JPEGs.
+	quality = 85
+)
+
+var (
+	backgroundColor = color.RGBA{R: 0x33, G: 0x33, B: 0x33, A: 0xff}
+	brandColor      = color.RGBA{R: 0xc2, G: 0x8e, B: 0x0e, A: 0xff}
+	titleColor      = color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}
+	subtitleColor   = color.RGBA{R: 0xdd, G: 0xdd, B: 0xdd, A: 0xff}
+)
+
+// layout, in pixels
+const (
+	textLeft  = Height + 60
+	textWidth = Width - textLeft - 60
+
+	titleBaseline   = 190
+	maxTitleSize    = 80
+	minTitleSize    = 44
+	subtitleSize    = 34
+	maxSubtitleRows = 5
+
+	brandBaseline   = 540
+	brandSize       = 40
+	websiteBaseline = 585
+	websiteSize     = 28
+
+	stripeHeight = 14
+)
+
+// Card is what's shown on a social card: a photo down the left side, and a title and subtitle to the right of it.
+type Card struct {
+	// Photo is the path of the photo (which may be a WebP, JPEG, or PNG), relative to the working directory. It should
+	// be cache-busted, so that a changed photo changes the card's hash.
+	Photo string
+
+	// Title is the card's headline (e.g. a Purdoobah's nickname), which is shrunk to fit.
+	Title string
+
+	// Subtitle is wrapped under the title, and cut off if it runs too long.
+	Subtitle string
+}
+
+// Hash uniquely identifies everything that's drawn on the card.
+func (c *Card) Hash() string {
+	return fmt.Sprintf("%x", md5.Sum([]byte(fmt.Sprintf(
+		"%d\x00%s\x00%s\x00%s",
+		version, c.Photo, c.Title, c.Subtitle,
+	))))
+}
+
+// Renderer draws social cards as JPEGs, and keeps them in a cache on disk (by hash) so every card is only drawn once.
+type Renderer struct {
+	cacheDir string
+
+	regular *sfnt.Font
+	bold    *sfnt.Font
+
+	// locks keep the same card from being drawn more than once at a time, by hash
+	locks *keylock.KeyLock
+}
+
+func NewRenderer(cacheDir string) (*Renderer, error) {
+	err := os.MkdirAll(cacheDir, 0755)
+	if err != nil {
+		return &Renderer{}, err
+	}
+
+	// the fonts are embedded, so cards look the same wherever they're drawn; Liberation Serif is a stand-in for the
+	// site's Times New Roman, which can't be redistributed
+	regular, err := opentype.Parse(liberationserifregular.TTF)
+	if err != nil {
+		return &Renderer{}, err
+	}
+	bold, err := opentype.Parse(liberationserifbold.TTF)
+	if err != nil {
+		return &Renderer{}, err
+	}
+
+	return &Renderer{
+		cacheDir: cacheDir,
+		regular:  regular,
+		bold:     bold,
+		locks:    keylock.NewKeyLock(),
+	}, nil
+}
+
+// Render returns the path of the card's JPEG, drawing it if it isn't in the cache yet.
+func (rd *Renderer) Render(c *Card) (string, error) {
+	hash := c.Hash()
+	cardPath := filepath.Join(rd.cacheDir, fmt.Sprintf("%s.jpg", hash))
+
+	rd.locks.Lock(hash)
+	defer rd.locks.Unlock(hash)
+
+	if _, err := os.Stat(cardPath); err == nil {
+		return cardPath, nil
+	}
+
+	img, err := rd.draw(c)
+	if err != nil {
+		return "", err
+	}
+
+	// write to a temporary file first, so that a half-written card is never served
+	f, err := os.CreateTemp(rd.cacheDir, ".card-*")
+	if err != nil {
+		return "", err
+	}
+	tempPath := f.Name()
+	f.Close()
+	defer os.Remove(tempPath)
+
+	err = imaging.WriteJPEG(tempPath, img, quality)
+	if err != nil {
+		return "", err
+	}
+
+	// temporary files are only readable by their owner
+	err = os.Chmod(tempPath, 0644)
+	if err != nil {
+		return "", err
+	}
+
+	err = os.Rename(tempPath, cardPath)
+	if err != nil {
+		return "", err
+	}
+	return cardPath, nil
+}
+
+func (rd *Renderer) draw(c *Card) (image.Image, error) {
+	card := image.NewRGBA(image.Rect(0, 0, Width, Height))
+	draw.Draw(card, card.Bounds(), image.NewUniform(backgroundColor), image.Point{}, draw.Src)
+
+	// photo, as a square down the left side
+	photo, err := imaging.Decode(c.Photo)
+	if err != nil {
+		return nil, err
+	}
+	draw.Draw(card, image.Rect(0, 0, Height, Height), imaging.Fill(photo, Height, Height), image.Point{}, draw.Over)
+
+	// brand-colored stripe along the bottom of the text
+	draw.Draw(card, image.Rect(Height, Height-stripeHeight, Width, Height), image.NewUniform(brandColor), image.Point{}, draw.Src)
+
+	// title, shrunk until it fits on one line
+	titleFace, err := fit(rd.bold, c.Title, maxTitleSize, minTitleSize, textWidth)
+	if err != nil {
+		return nil, err
+	}
+	defer titleFace.Close()
+	title := truncate(titleFace, c.Title, textWidth)
+	drawText(card, titleFace, titleColor, title, textLeft, titleBaseline)
+
+	// subtitle, wrapped under the title
+	subtitleFace, err := newFace(rd.regular, subtitleSize)
+	if err != nil {
+		return nil, err
+	}
+	defer subtitleFace.Close()
+	lineHeight := subtitleSize * 13 / 10
+	baseline := titleBaseline + lineHeight*2
+	for _, row := range wrap(subtitleFace, c.Subtitle, textWidth, maxSubtitleRows) {
+		drawText(card, subtitleFace, subtitleColor, row, textLeft, baseline)
+		baseline += lineHeight
+	}
+
+	// branding
+	brandFace, err := newFace(rd.bold, brandSize)
+	if err != nil {
+		return nil, err
+	}
+	defer brandFace.Close()
+	drawText(card, brandFace, brandColor, "Purdoobahs ΨΜΣΗ", textLeft, brandBaseline)
+
+	websiteFace, err := newFace(rd.regular, websiteSize)
+	if err != nil {
+		return nil, err
+	}
+	defer websiteFace.Close()
+	drawText(card, websiteFace, subtitleColor, "purdoobahs.com", textLeft, websiteBaseline)
+
+	return card, nil
+}
+
+// fit returns the largest face (down to the minimum size) that the text fits within the given width in.
+func fit(f *sfnt.Font, text string, maxSize, minSize, width int) (font.Face, error) {
+	for size := maxSize; ; size -= 4 {
+		face, err := newFace(f, size)
+		if err != nil {
+			return nil, err
+		}
+		if size <= minSize || font.MeasureString(face, text).Ceil() <= width {
+			return face, nil
+		}
+		face.Close()
+	}
+}
+
+// Prune deletes every cached card that isn't current anymore (i.e. that's since changed, or whose page is gone), along
+// with any temporary files left behind, returning how many files were deleted. It should be called before any cards
+// are asked for, so that no card is deleted while it's being drawn.
+func (rd *Renderer) Prune(isCurrent func(hash string) bool) (int, error) {
+	entries, err := os.ReadDir(rd.cacheDir)
+	if err != nil {
+		return 0, err
+	}
+
+	pruned := 0
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		// cards are named "<hash>.jpg"
+		name := entry.Name()
+		hash := strings.TrimSuffix(name, ".jpg")
+		if hash != name && !strings.HasPrefix(name, ".") && isCurrent(hash) {
+			continue
+		}
+
+		err = os.Remove(filepath.Join(rd.cacheDir, name))
+		if err != nil {
+			return pruned, err
+		}
+		pruned++
+	}
+	return pruned, nil
+}
+
+func newFace(f *sfnt.Font, size int) (font.Face, error) {
+	return opentype.NewFace(f, &opentype.FaceOptions{
+		Size:    float64(size),
+		DPI:     72,
+		Hinting: font.HintingFull,
+	})
+}
+
+func drawText(dst draw.Image, face font.Face, c color.Color, text string, x, y int) {
+	d := &font.Drawer{
+		Dst:  dst,
+		Src:  image.NewUniform(c),
+		Face: face,
+		Dot:  fixed.P(x, y),
+	}
+	d.DrawString(text)
+}
+
+// wrap breaks the text into rows that fit within the given width, cutting it off with an ellipsis after so many rows.
+func wrap(face font.Face, text string, width, maxRows int) []string {
+	var rows []string
+	row := ""
+	for _, word := range strings.Fields(text) {
+		candidate := word
+		if row != "" {
+			candidate = fmt.Sprintf("%s %s", row, word)
+		}
+		if font.MeasureString(face, candidate).Ceil() <= width || row == "" {
+			row = candidate
+			continue
+		}
+
+		// the last row ends with what doesn't fit, so that it gets cut off
+		if len(rows) == maxRows-1 {
+			return append(rows, truncate(face, candidate, width))
+		}
+		rows = append(rows, truncate(face, row, width))
+		row = word
+	}
+	if row != "" {
+		rows = append(rows, truncate(face, row, width))
+	}
+	return rows
+}
+
+// truncate cuts the text short with an ellipsis, if it doesn't fit within the given width.
+func truncate(face font.Face, text string, width int) string {
+	if font.MeasureString(face, text).Ceil() <= width {
+		return text
+	}
+
+	runes := []rune(text)
+	for len(runes) > 0 {
+		runes = runes[:len(runes)-1]
+		candidate := fmt.Sprintf("%s…", strings.TrimSpace(string(runes)))
+		if font.MeasureString(face, candidate).Ceil() <= width {
+			return candidate
+		}
+	}
+	return "…"
+}
@@ -0,0 +1,102 @@
+package spamguard
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrHoneypot is returned when the honeypot field was filled in, which only bots do.
+	ErrHoneypot = errors.New("the honeypot field was filled in")
+
+	// ErrInvalidToken is returned when the form's token is missing or wasn't handed out by us.
+	ErrInvalidToken = errors.New("the form token is invalid")
+
+	// ErrTooFast is returned when the form was submitted quicker than a person could fill it in.
+	ErrTooFast = errors.New("the form was submitted too quickly")
+
+	// ErrExpired is returned when the form was rendered too long ago.
+	ErrExpired = errors.New("the form has expired")
+)
+
+// SpamGuard keeps bots from submitting public forms, without making people solve a CAPTCHA.
+//
+// Every form gets a hidden honeypot field, which people never see but bots fill in, and a signed token holding the
+// time the form was rendered, which rejects forms that are submitted quicker than a person could fill them in.
+type SpamGuard struct {
+	// HoneypotFieldName is the name of the form field that has to be left empty.
+	HoneypotFieldName string
+
+	// TokenFieldName is the name of the form field holding the token.
+	TokenFieldName string
+
+	// MinDelay is how long a person needs to fill in the form, at the very least.
+	MinDelay time.Duration
+
+	// MaxAge is how long a rendered form can be submitted for.
+	MaxAge time.Duration
+
+	key []byte
+}
+
+func NewSpamGuard() (*SpamGuard, error) {
+	// tokens only have to survive until the form is submitted, so a new key is made on every start
+	key := make([]byte, 32)
+	_, err := rand.Read(key)
+	if err != nil {
+		return &SpamGuard{}, err
+	}
+
+	return &SpamGuard{
+		HoneypotFieldName: "website",
+		TokenFieldName:    "form_token",
+		MinDelay:          3 * time.Second,
+		MaxAge:            24 * time.Hour,
+		key:               key,
+	}, nil
+}
+
+// Token returns the token of a form rendered at the given time.
+func (sg *SpamGuard) Token(now time.Time) string {
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	return fmt.Sprintf("%s.%s", timestamp, sg.sign(timestamp))
+}
+
+// Check returns an error if the submitted form looks like it came from a bot. The form has to be parsed already.
+func (sg *SpamGuard) Check(r *http.Request, now time.Time) error {
+	if r.PostForm.Get(sg.HoneypotFieldName) != "" {
+		return ErrHoneypot
+	}
+
+	timestamp, signature, ok := strings.Cut(r.PostForm.Get(sg.TokenFieldName), ".")
+	if !ok || !hmac.Equal([]byte(signature), []byte(sg.sign(timestamp))) {
+		return ErrInvalidToken
+	}
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return ErrInvalidToken
+	}
+
+	elapsed := now.Sub(time.Unix(seconds, 0))
+	switch {
+	case elapsed < sg.MinDelay:
+		return ErrTooFast
+	case elapsed > sg.MaxAge:
+		return ErrExpired
+	}
+	return nil
+}
+
+func (sg *SpamGuard) sign(timestamp string) string {
+	mac := hmac.New(sha256.New, sg.key)
+	mac.Write([]byte(timestamp))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,172 @@
+package stats
+
+import (
+	"sort"
+
+	"github.com/purdoobahs/purdoobahs.com/internal/purdoobahs"
+)
+
+// cohortSeasons is how many seasons a cohort is followed for: freshman through super-senior year.
+const cohortSeasons = 5
+
+// Cohorts is the retention of every freshman cohort.
+type Cohorts struct {
+	Cohorts []*Cohort `json:"cohorts"`
+
+	// Average pools every cohort together, only counting the cohorts each season was observed for.
+	Average []*Retention `json:"average"`
+
+	// UnknownHistory is how many Purdoobahs we don't know the marching history of, who can't be placed in a cohort.
+	UnknownHistory int `json:"unknown_history"`
+}
+
+// Cohort is every Purdoobah whose first season was the same year.
+type Cohort struct {
+	Year int `json:"year"`
+	Size int `json:"size"`
+
+	// Retention holds one entry per season, starting with the cohort's first (which is always 100%).
+	Retention []*Retention `json:"retention"`
+
+	// Returners is how many members skipped at least one season and then came back.
+	Returners int `json:"returners"`
+}
+
+// Retention is how many members of a cohort marched during a given season of theirs.
+type Retention struct {
+	// Season is 1 for the cohort's first season (freshman year), 2 for their second, and so on.
+	Season int `json:"season"`
+
+	// Year is the calendar year of the season, which is left out of pooled averages.
+	Year int `json:"year,omitempty"`
+
+	// Observed is false when the season hasn't happened yet, or falls in a gap of the archives, so the count is
+	// meaningless.
+	Observed bool    `json:"observed"`
+	Count    int     `json:"count"`
+	Percent  float64 `json:"percent"`
+}
+
+// ComputeCohorts groups every Purdoobah into a cohort by their first season, and follows each cohort through their
+// following seasons.
+//
+// A member counts towards a season if they marched that calendar year, so someone who skipped a year still counts
+// towards the seasons they came back for.
+func ComputeCohorts(purdoobahService purdoobahs.IPurdoobahService) (*Cohorts, error) {
+	allPurdoobahs, err := purdoobahService.All()
+	if err != nil {
+		return &Cohorts{}, err
+	}
+
+	sectionYears, err := purdoobahService.AllSectionYears()
+	if err != nil {
+		return &Cohorts{}, err
+	}
+
+	// a season is only observed if it has already happened and made it into the archives
+	archived := make(map[int]bool)
+	for _, year := range sectionYears {
+		archived[year] = true
+	}
+	currentAcademicYear := purdoobahs.CurrentAcademicYear()
+	observed := func(year int) bool {
+		return year <= currentAcademicYear && archived[year]
+	}
+
+	c := &Cohorts{
+		Cohorts: make([]*Cohort, 0),
+		Average: make([]*Retention, 0, cohortSeasons),
+	}
+
+	// group by first season
+	members := make(map[int][]*purdoobahs.Purdoobah)
+	for _, p := range allPurdoobahs {
+		if !p.HasKnownHistory() || len(p.Marching.YearsMarched) == 0 {
+			c.UnknownHistory++
+			continue
+		}
+		firstYear := firstSeason(p)
+		members[firstYear] = append(members[firstYear], p)
+	}
+
+	for year, cohortMembers := range members {
+		cohort := &Cohort{
+			Year:      year,
+			Size:      len(cohortMembers),
+			Retention: make([]*Retention, 0, cohortSeasons),
+		}
+
+		for season := 1; season <= cohortSeasons; season++ {
+			retention := &Retention{
+				Season:   season,
+				Year:     year + season - 1,
+				Observed: observed(year + season - 1),
+			}
+			if retention.Observed {
+				for _, p := range cohortMembers {
+					if p.MarchedDuringYear(retention.Year) {
+						retention.Count++
+					}
+				}
+				retention.Percent = round(100 * float64(retention.Count) / float64(cohort.Size))
+			}
+			cohort.Retention = append(cohort.Retention, retention)
+		}
+
+		for _, p := range cohortMembers {
+			if hasGap(p) {
+				cohort.Returners++
+			}
+		}
+
+		c.Cohorts = append(c.Cohorts, cohort)
+	}
+
+	sort.Slice(c.Cohorts, func(i, j int) bool {
+		return c.Cohorts[i].Year < c.Cohorts[j].Year
+	})
+
+	// pool every cohort that was observed for each season
+	for season := 1; season <= cohortSeasons; season++ {
+		average := &Retention{Season: season}
+		size := 0
+		for _, cohort := range c.Cohorts {
+			retention := cohort.Retention[season-1]
+			if !retention.Observed {
+				continue
+			}
+			average.Observed = true
+			average.Count += retention.Count
+			size += cohort.Size
+		}
+		if size > 0 {
+			average.Percent = round(100 * float64(average.Count) / float64(size))
+		}
+		c.Average = append(c.Average, average)
+	}
+
+	return c, nil
+}
+
+// firstSeason returns the earliest year the Purdoobah marched.
+func firstSeason(p *purdoobahs.Purdoobah) int {
+	first := p.Marching.YearsMarched[0]
+	for _, year := range p.Marching.YearsMarched {
+		if year < first {
+			first = year
+		}
+	}
+	return first
+}
+
+// hasGap returns whether the Purdoobah skipped at least one season between their first and last.
+func hasGap(p *purdoobahs.Purdoobah) bool {
+	years := append([]int(nil), p.Marching.YearsMarched...)
+	sort.Ints(years)
+	for i := 1; i < len(years); i++ {
+		if years[i]-years[i-1] > 1 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,216 @@
+package stats
+
+import (
+	"math"
+	"sort"
+
+	"github.com/purdoobahs/purdoobahs.com/internal/purdoobahs"
+)
+
+// unknown is the placeholder the asset files use for details that have been lost to time.
+const unknown = "unknown"
+
+// Stats is an aggregated view of the section over time.
+type Stats struct {
+	TotalPurdoobahs int `json:"total_purdoobahs"`
+
+	// UnknownHistory is how many Purdoobahs we don't know the marching history of (the section of year -1).
+	UnknownHistory int `json:"unknown_history"`
+
+	// Years holds one entry per known section year, oldest first.
+	Years []*YearStats `json:"years"`
+
+	HomeStates *Distribution `json:"home_states"`
+	Majors     *Distribution `json:"majors"`
+
+	KappaKappaPsi *Rate `json:"kappa_kappa_psi"`
+	TauBetaSigma  *Rate `json:"tau_beta_sigma"`
+
+	// AverageTenure is the average number of seasons marched, over Purdoobahs with a known marching history.
+	AverageTenure float64 `json:"average_tenure"`
+}
+
+// YearStats is the makeup of a single year's section.
+type YearStats struct {
+	Year           int            `json:"year"`
+	Size           int            `json:"size"`
+	StudentLeaders int            `json:"student_leaders"`
+	ClassStanding  *ClassStanding `json:"class_standing"`
+}
+
+// ClassStanding counts a section's members by their year in school, as derived from how many seasons they had
+// marched by then.
+type ClassStanding struct {
+	Freshmen     int `json:"freshmen"`
+	Sophomores   int `json:"sophomores"`
+	Juniors      int `json:"juniors"`
+	Seniors      int `json:"seniors"`
+	SuperSeniors int `json:"super_seniors"`
+}
+
+// Distribution counts how often each value occurs, most common first.
+type Distribution struct {
+	Counts []*Count `json:"counts"`
+
+	// Unknown is how many Purdoobahs have no known value.
+	Unknown int `json:"unknown"`
+}
+
+type Count struct {
+	Name    string  `json:"name"`
+	Count   int     `json:"count"`
+	Percent float64 `json:"percent"`
+}
+
+// Rate is the share of Purdoobahs that something is true for.
+type Rate struct {
+	Count   int     `json:"count"`
+	Total   int     `json:"total"`
+	Percent float64 `json:"percent"`
+}
+
+// Compute aggregates statistics over every Purdoobah.
+func Compute(purdoobahService purdoobahs.IPurdoobahService) (*Stats, error) {
+	allPurdoobahs, err := purdoobahService.All()
+	if err != nil {
+		return &Stats{}, err
+	}
+
+	sectionYears, err := purdoobahService.AllSectionYears()
+	if err != nil {
+		return &Stats{}, err
+	}
+
+	s := &Stats{
+		TotalPurdoobahs: len(allPurdoobahs),
+		Years:           make([]*YearStats, 0, len(sectionYears)),
+	}
+
+	// per-year section makeup
+	for _, year := range sectionYears {
+		if year == -1 {
+			continue
+		}
+
+		section, err := purdoobahService.SectionByYear(year)
+		if err != nil {
+			return &Stats{}, err
+		}
+		s.Years = append(s.Years, computeYear(year, section))
+	}
+
+	// demographics
+	homeStates := make([]string, 0, len(allPurdoobahs))
+	majors := make([]string, 0, len(allPurdoobahs))
+	for _, p := range allPurdoobahs {
+		homeStates = append(homeStates, p.Hometown.State)
+		majors = append(majors, p.Education.Major)
+	}
+	s.HomeStates = newDistribution(homeStates)
+	s.Majors = newDistribution(majors)
+
+	// memberships
+	kappaKappaPsi, tauBetaSigma := 0, 0
+	for _, p := range allPurdoobahs {
+		if p.Achievements.KappaKappaPsi {
+			kappaKappaPsi++
+		}
+		if p.Achievements.TauBetaSigma {
+			tauBetaSigma++
+		}
+	}
+	s.KappaKappaPsi = newRate(kappaKappaPsi, len(allPurdoobahs))
+	s.TauBetaSigma = newRate(tauBetaSigma, len(allPurdoobahs))
+
+	// tenure
+	seasons, knownHistory := 0, 0
+	for _, p := range allPurdoobahs {
+		if !p.HasKnownHistory() {
+			s.UnknownHistory++
+			continue
+		}
+		seasons += len(p.Marching.YearsMarched)
+		knownHistory++
+	}
+	if knownHistory > 0 {
+		s.AverageTenure = round(float64(seasons) / float64(knownHistory))
+	}
+
+	return s, nil
+}
+
+func computeYear(year int, section []*purdoobahs.Purdoobah) *YearStats {
+	ys := &YearStats{
+		Year:          year,
+		Size:          len(section),
+		ClassStanding: &ClassStanding{},
+	}
+
+	for _, p := range section {
+		if p.IsStudentLeaderInYear(year) {
+			ys.StudentLeaders++
+		}
+
+		switch p.ClassStandingInYear(year) {
+		case purdoobahs.Freshman:
+			ys.ClassStanding.Freshmen++
+		case purdoobahs.Sophomore:
+			ys.ClassStanding.Sophomores++
+		case purdoobahs.Junior:
+			ys.ClassStanding.Juniors++
+		case purdoobahs.Senior:
+			ys.ClassStanding.Seniors++
+		case purdoobahs.SuperSenior:
+			ys.ClassStanding.SuperSeniors++
+		}
+	}
+
+	return ys
+}
+
+// newDistribution counts the values, ignoring empty and unknown ones.
+func newDistribution(values []string) *Distribution {
+	d := &Distribution{Counts: make([]*Count, 0)}
+
+	counts := make(map[string]int)
+	known := 0
+	for _, value := range values {
+		if value == "" || value == unknown {
+			d.Unknown++
+			continue
+		}
+		counts[value]++
+		known++
+	}
+
+	for name, count := range counts {
+		d.Counts = append(d.Counts, &Count{
+			Name:    name,
+			Count:   count,
+			Percent: round(100 * float64(count) / float64(known)),
+		})
+	}
+
+	// most common first, ties broken alphabetically
+	sort.Slice(d.Counts, func(i, j int) bool {
+		if d.Counts[i].Count != d.Counts[j].Count {
+			return d.Counts[i].Count > d.Counts[j].Count
+		}
+		return d.Counts[i].Name < d.Counts[j].Name
+	})
+
+	return d
+}
+
+func newRate(count, total int) *Rate {
+	r := &Rate{Count: count, Total: total}
+	if total > 0 {
+		r.Percent = round(100 * float64(count) / float64(total))
+	}
+	return r
+}
+
+// round rounds to two decimal places.
+func round(f float64) float64 {
+	return math.Round(f*100) / 100
+}
@@ -0,0 +1,80 @@
+package svgchart
+
+import (
+	"fmt"
+	"html/template"
+)
+
+// Bar is a single labeled value.
+type Bar struct {
+	Label string
+	Value float64
+}
+
+// BarChart is a horizontal bar chart, which suits long category names.
+type BarChart struct {
+	Title       string
+	Description string
+	Bars        []Bar
+
+	// Color overrides the first Palette color.
+	Color string
+
+	Width int
+
+	// LabelWidth is the space reserved for the category names to the left of the bars.
+	LabelWidth int
+}
+
+// SVG renders the chart as inline SVG markup. Its height grows with the number of bars.
+func (c *BarChart) SVG() template.HTML {
+	const (
+		barHeight   = 20.0
+		gap         = 6.0
+		marginRight = 40.0
+	)
+
+	color := c.Color
+	if color == "" {
+		color = Palette[5]
+	}
+
+	max := 0.0
+	for _, bar := range c.Bars {
+		if bar.Value > max {
+			max = bar.Value
+		}
+	}
+	if max == 0 {
+		max = 1
+	}
+
+	labelWidth := float64(c.LabelWidth)
+	plotWidth := float64(c.Width) - labelWidth - marginRight
+	height := int(float64(len(c.Bars))*(barHeight+gap) + gap)
+
+	b := &builder{}
+	b.open(c.Width, height, c.Title, c.Description)
+
+	for i, bar := range c.Bars {
+		y := gap + float64(i)*(barHeight+gap)
+		width := plotWidth * bar.Value / max
+
+		b.text(labelWidth-6, y+barHeight/2+fontSize/3, "end", "", truncate(bar.Label, labelWidth))
+		b.rect(labelWidth, y, width, barHeight, color, fmt.Sprintf("%s: %s", bar.Label, num(bar.Value)))
+		b.text(labelWidth+width+4, y+barHeight/2+fontSize/3, "start", "", num(bar.Value))
+	}
+
+	b.close()
+	return template.HTML(b.String())
+}
+
+// truncate shortens a label to roughly fit within the given width; the full label remains in the bar's tooltip.
+func truncate(label string, width float64) string {
+	maxRunes := int(width / (fontSize * 0.55))
+	runes := []rune(label)
+	if maxRunes < 2 || len(runes) <= maxRunes {
+		return label
+	}
+	return string(runes[:maxRunes-1]) + "…"
+}
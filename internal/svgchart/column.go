@@ -0,0 +1,109 @@
+package svgchart
+
+import (
+	"fmt"
+	"html/template"
+)
+
+// Series is a named list of values, one per column.
+type Series struct {
+	Name   string
+	Values []float64
+}
+
+// ColumnChart is a vertical bar chart. With multiple series, the columns are stacked.
+type ColumnChart struct {
+	Title       string
+	Description string
+
+	// Labels names each column along the x-axis.
+	Labels []string
+	Series []Series
+
+	// Colors overrides the Palette, one color per series.
+	Colors []string
+
+	Width  int
+	Height int
+}
+
+// SVG renders the chart as inline SVG markup.
+func (c *ColumnChart) SVG() template.HTML {
+	const (
+		ticks        = 5
+		marginTop    = 30.0
+		marginRight  = 10.0
+		marginBottom = 50.0
+		marginLeft   = 40.0
+	)
+
+	colors := c.Colors
+	if len(colors) < len(c.Series) {
+		colors = Palette
+	}
+
+	// the tallest stack sets the scale
+	max := 0.0
+	for i := range c.Labels {
+		total := 0.0
+		for _, series := range c.Series {
+			if i < len(series.Values) {
+				total += series.Values[i]
+			}
+		}
+		if total > max {
+			max = total
+		}
+	}
+	max = niceMax(max, ticks)
+
+	plotWidth := float64(c.Width) - marginLeft - marginRight
+	plotHeight := float64(c.Height) - marginTop - marginBottom
+	bottom := marginTop + plotHeight
+
+	b := &builder{}
+	b.open(c.Width, c.Height, c.Title, c.Description)
+
+	// gridlines
+	for i := 0; i <= ticks; i++ {
+		value := max * float64(i) / ticks
+		y := bottom - plotHeight*float64(i)/ticks
+		b.line(marginLeft, y, marginLeft+plotWidth, y, "currentColor", 0.25)
+		b.text(marginLeft-6, y+fontSize/3, "end", "", num(value))
+	}
+
+	// columns
+	if len(c.Labels) > 0 {
+		slot := plotWidth / float64(len(c.Labels))
+		width := slot * 0.8
+		for i, label := range c.Labels {
+			x := marginLeft + slot*float64(i) + (slot-width)/2
+			y := bottom
+			for s, series := range c.Series {
+				if i >= len(series.Values) || series.Values[i] == 0 {
+					continue
+				}
+				height := plotHeight * series.Values[i] / max
+				y -= height
+				b.rect(x, y, width, height, colors[s%len(colors)], fmt.Sprintf("%s: %s %s", label, num(series.Values[i]), series.Name))
+			}
+
+			// x-axis labels are angled so that years don't overlap
+			labelX := x + width/2
+			labelY := bottom + fontSize + 4
+			b.text(labelX, labelY, "end", fmt.Sprintf("rotate(-45 %s %s)", num(labelX), num(labelY)), label)
+		}
+	}
+
+	// legend
+	if len(c.Series) > 1 {
+		names := make([]string, 0, len(c.Series))
+		for _, series := range c.Series {
+			names = append(names, series.Name)
+		}
+		b.legend(marginLeft, fontSize+4, names, colors, 0)
+	}
+
+	b.close()
+	return template.HTML(b.String())
+}
@@ -0,0 +1,116 @@
+package svgchart
+
+import (
+	"fmt"
+	"html"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// fontSize is the size of every label, in pixels.
+const fontSize = 12
+
+// Palette is the default series colors, matching the Purdoobah card colors.
+var Palette = []string{
+	"#f9beff", // freshman
+	"#3bcaef", // sophomore
+	"#62bb47", // junior
+	"#ed3742", // senior
+	"#ba55d3", // super-senior
+	"#c28e0e", // section leader
+}
+
+// builder writes SVG markup.
+//
+// Styling is done entirely with presentation attributes, as the Content Security Policy blocks inline styles.
+type builder struct {
+	strings.Builder
+}
+
+func (b *builder) open(width, height int, title, description string) {
+	b.WriteString(fmt.Sprintf(
+		`<svg xmlns="http://www.w3.org/2000/svg" class="chart" viewBox="0 0 %d %d" role="img" aria-labelledby="%s-title %s-desc" font-size="%d" fill="currentColor">`,
+		width, height, id(title), id(title), fontSize,
+	))
+	b.WriteString(fmt.Sprintf(`<title id="%s-title">%s</title>`, id(title), html.EscapeString(title)))
+	b.WriteString(fmt.Sprintf(`<desc id="%s-desc">%s</desc>`, id(title), html.EscapeString(description)))
+}
+
+func (b *builder) close() {
+	b.WriteString(`</svg>`)
+}
+
+func (b *builder) text(x, y float64, anchor, transform, s string) {
+	if transform != "" {
+		transform = fmt.Sprintf(` transform="%s"`, transform)
+	}
+	b.WriteString(fmt.Sprintf(
+		`<text x="%s" y="%s" text-anchor="%s"%s>%s</text>`,
+		num(x), num(y), anchor, transform, html.EscapeString(s),
+	))
+}
+
+func (b *builder) line(x1, y1, x2, y2 float64, stroke string, opacity float64) {
+	b.WriteString(fmt.Sprintf(
+		`<line x1="%s" y1="%s" x2="%s" y2="%s" stroke="%s" stroke-opacity="%s"/>`,
+		num(x1), num(y1), num(x2), num(y2), stroke, num(opacity),
+	))
+}
+
+// rect draws a rectangle with a tooltip.
+func (b *builder) rect(x, y, width, height float64, fill, tooltip string) {
+	b.WriteString(fmt.Sprintf(
+		`<rect x="%s" y="%s" width="%s" height="%s" fill="%s"><title>%s</title></rect>`,
+		num(x), num(y), num(width), num(height), fill, html.EscapeString(tooltip),
+	))
+}
+
+// legend draws one swatch per series name, left to right, starting at (x, y). The colors of the series are picked
+// starting at the given offset, wrapping around.
+func (b *builder) legend(x, y float64, names []string, colors []string, offset int) {
+	for i, name := range names {
+		b.WriteString(fmt.Sprintf(
+			`<rect x="%s" y="%s" width="%d" height="%d" fill="%s"/>`,
+			num(x), num(y-fontSize+2), fontSize, fontSize, colors[(offset+i)%len(colors)],
+		))
+		b.text(x+fontSize+4, y, "start", "", name)
+		x += float64(fontSize+12) + float64(len(name))*fontSize*0.6
+	}
+}
+
+// niceMax rounds max up to a value that divides evenly into the given number of gridlines.
+func niceMax(max float64, ticks int) float64 {
+	if max <= 0 {
+		return float64(ticks)
+	}
+
+	step := max / float64(ticks)
+	magnitude := math.Pow(10, math.Floor(math.Log10(step)))
+	for _, multiple := range []float64{1, 2, 2.5, 5, 10} {
+		if multiple*magnitude >= step {
+			step = multiple * magnitude
+			break
+		}
+	}
+	return step * float64(ticks)
+}
+
+// num formats a coordinate without any trailing zeroes.
+func num(f float64) string {
+	return strconv.FormatFloat(math.Round(f*100)/100, 'f', -1, 64)
+}
+
+// id turns a title into an element ID, so that multiple charts on a page don't collide.
+func id(title string) string {
+	var sb strings.Builder
+	sb.WriteString("chart-")
+	for _, r := range strings.ToLower(title) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			sb.WriteRune(r)
+		} else {
+			sb.WriteRune('-')
+		}
+	}
+	return sb.String()
+}
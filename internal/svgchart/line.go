@@ -0,0 +1,134 @@
+package svgchart
+
+import (
+	"fmt"
+	"html"
+	"html/template"
+	"math"
+	"strings"
+)
+
+// LineChart plots one line per series. Values that are NaN are gaps in their line.
+type LineChart struct {
+	Title       string
+	Description string
+
+	// Labels names each point along the x-axis.
+	Labels []string
+	Series []Series
+
+	// Colors overrides the Palette, one color per series.
+	Colors []string
+
+	// Max fixes the top of the y-axis (e.g. 100 for percentages); otherwise it's derived from the values.
+	Max float64
+
+	// Unit is appended to the y-axis labels (e.g. "%").
+	Unit string
+
+	Width  int
+	Height int
+}
+
+// SVG renders the chart as inline SVG markup.
+func (c *LineChart) SVG() template.HTML {
+	const (
+		ticks        = 5
+		marginRight  = 20.0
+		marginBottom = 30.0
+		marginLeft   = 50.0
+	)
+
+	colors := c.Colors
+	if len(colors) < len(c.Series) {
+		colors = Palette
+	}
+
+	// the legend wraps, so it needs a row for every few series
+	legendRows := (len(c.Series) + 5) / 6
+	marginTop := 16.0 + float64(legendRows)*(fontSize+8)
+
+	max := c.Max
+	if max <= 0 {
+		for _, series := range c.Series {
+			for _, value := range series.Values {
+				if !math.IsNaN(value) && value > max {
+					max = value
+				}
+			}
+		}
+		max = niceMax(max, ticks)
+	}
+
+	plotWidth := float64(c.Width) - marginLeft - marginRight
+	plotHeight := float64(c.Height) - marginTop - marginBottom
+	bottom := marginTop + plotHeight
+
+	x := func(i int) float64 {
+		if len(c.Labels) < 2 {
+			return marginLeft + plotWidth/2
+		}
+		return marginLeft + plotWidth*float64(i)/float64(len(c.Labels)-1)
+	}
+	y := func(value float64) float64 {
+		return bottom - plotHeight*value/max
+	}
+
+	b := &builder{}
+	b.open(c.Width, c.Height, c.Title, c.Description)
+
+	// gridlines
+	for i := 0; i <= ticks; i++ {
+		value := max * float64(i) / ticks
+		b.line(marginLeft, y(value), marginLeft+plotWidth, y(value), "currentColor", 0.25)
+		b.text(marginLeft-6, y(value)+fontSize/3, "end", "", num(value)+c.Unit)
+	}
+	for i, label := range c.Labels {
+		b.text(x(i), bottom+fontSize+6, "middle", "", label)
+	}
+
+	// lines, broken up wherever a value is missing
+	for s, series := range c.Series {
+		color := colors[s%len(colors)]
+
+		var points []string
+		flush := func() {
+			if len(points) > 1 {
+				b.WriteString(fmt.Sprintf(
+					`<polyline points="%s" fill="none" stroke="%s" stroke-width="2"/>`,
+					strings.Join(points, " "), color,
+				))
+			}
+			points = nil
+		}
+		for i, value := range series.Values {
+			if i >= len(c.Labels) || math.IsNaN(value) {
+				flush()
+				continue
+			}
+			points = append(points, fmt.Sprintf("%s,%s", num(x(i)), num(y(value))))
+			b.WriteString(fmt.Sprintf(
+				`<circle cx="%s" cy="%s" r="3" fill="%s"><title>%s</title></circle>`,
+				num(x(i)), num(y(value)), color,
+				html.EscapeString(fmt.Sprintf("%s, %s: %s%s", series.Name, c.Labels[i], num(value), c.Unit)),
+			))
+		}
+		flush()
+	}
+
+	// legend
+	names := make([]string, 0, len(c.Series))
+	for _, series := range c.Series {
+		names = append(names, series.Name)
+	}
+	for row := 0; row < legendRows; row++ {
+		start, end := row*6, row*6+6
+		if end > len(names) {
+			end = len(names)
+		}
+		b.legend(marginLeft, float64(fontSize+4+row*(fontSize+8)), names[start:end], colors, start)
+	}
+
+	b.close()
+	return template.HTML(b.String())
+}
@@ -0,0 +1,211 @@
+package svgmap
+
+import (
+	"fmt"
+	"html"
+	"html/template"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// fontSize is the size of every label, in pixels.
+const fontSize = 12
+
+// Dot is a single marked location. Its size grows with its count.
+type Dot struct {
+	Lat   float64
+	Lng   float64
+	Count int
+
+	// Label is shown as the dot's tooltip.
+	Label string
+}
+
+// Map draws dots on top of a region's outline.
+//
+// Styling is done entirely with presentation attributes, as the Content Security Policy blocks inline styles.
+type Map struct {
+	Title       string
+	Description string
+	Region      *Region
+	Dots        []Dot
+
+	// Color is the fill of the dots.
+	Color string
+
+	// Width is fixed; the height follows from the region's shape.
+	Width int
+}
+
+// frame is where a region is drawn within the map.
+type frame struct {
+	region        *Region
+	scale         float64
+	offsetX       float64
+	offsetY       float64
+	west, east    float64
+	south, north  float64
+	width, height float64
+}
+
+// SVG renders the map as inline SVG markup. Dots that fall outside of the region (and its insets) are left out.
+func (m *Map) SVG() template.HTML {
+	const padding = 10.0
+
+	color := m.Color
+	if color == "" {
+		color = "#c28e0e"
+	}
+
+	width := float64(m.Width)
+	main := newFrame(m.Region, width-2*padding, math.Inf(1))
+	main.offsetX += padding
+	main.offsetY += padding
+	height := main.height + 2*padding
+
+	frames := []*frame{main}
+	for _, inset := range m.Region.Insets {
+		f := newFrame(inset, width*0.18, height*0.2)
+		f.offsetX += padding
+		f.offsetY += height - padding - f.height
+		frames = append(frames, f)
+	}
+
+	var sb strings.Builder
+	titleID := id(m.Title)
+	sb.WriteString(fmt.Sprintf(
+		`<svg xmlns="http://www.w3.org/2000/svg" class="map" viewBox="0 0 %s %s" role="img" aria-labelledby="%s-title %s-desc" font-size="%d" fill="currentColor">`,
+		num(width), num(height), titleID, titleID, fontSize,
+	))
+	sb.WriteString(fmt.Sprintf(`<title id="%s-title">%s</title>`, titleID, html.EscapeString(m.Title)))
+	sb.WriteString(fmt.Sprintf(`<desc id="%s-desc">%s</desc>`, titleID, html.EscapeString(m.Description)))
+
+	for i, f := range frames {
+		// insets are boxed so they don't read as part of the main region
+		if i > 0 {
+			sb.WriteString(fmt.Sprintf(
+				`<rect x="%s" y="%s" width="%s" height="%s" fill="none" stroke="currentColor" stroke-opacity="0.3"/>`,
+				num(f.offsetX), num(f.offsetY), num(f.width), num(f.height),
+			))
+		}
+		for _, outline := range f.region.Land {
+			sb.WriteString(fmt.Sprintf(
+				`<path d="%s" fill="currentColor" fill-opacity="0.08" stroke="currentColor" stroke-opacity="0.4"/>`,
+				f.path(outline),
+			))
+		}
+		for _, outline := range f.region.Water {
+			sb.WriteString(fmt.Sprintf(`<path d="%s" fill="#3bcaef" fill-opacity="0.3"/>`, f.path(outline)))
+		}
+	}
+
+	// the biggest dots are drawn first, so that smaller ones stay visible on top of them
+	dots := make([]Dot, len(m.Dots))
+	copy(dots, m.Dots)
+	sort.SliceStable(dots, func(i, j int) bool {
+		return dots[i].Count > dots[j].Count
+	})
+	for _, dot := range dots {
+		for _, f := range frames {
+			if !f.contains(dot.Lng, dot.Lat) {
+				continue
+			}
+
+			x, y := f.point(dot.Lng, dot.Lat)
+			sb.WriteString(fmt.Sprintf(
+				`<circle cx="%s" cy="%s" r="%s" fill="%s" fill-opacity="0.85" stroke="#ffffff"><title>%s</title></circle>`,
+				num(x), num(y), num(radius(dot.Count)), color, html.EscapeString(dot.Label),
+			))
+			break
+		}
+	}
+
+	sb.WriteString(`</svg>`)
+	return template.HTML(sb.String())
+}
+
+// newFrame scales a region to fit within the given size, keeping its aspect ratio.
+func newFrame(region *Region, maxWidth, maxHeight float64) *frame {
+	f := &frame{
+		region: region,
+		west:   math.Inf(1), east: math.Inf(-1),
+		south: math.Inf(1), north: math.Inf(-1),
+	}
+
+	minX, minY := math.Inf(1), math.Inf(1)
+	maxX, maxY := math.Inf(-1), math.Inf(-1)
+	for _, outline := range region.Land {
+		for _, c := range outline {
+			f.west, f.east = math.Min(f.west, c[0]), math.Max(f.east, c[0])
+			f.south, f.north = math.Min(f.south, c[1]), math.Max(f.north, c[1])
+
+			x, y := region.projection.project(c[0], c[1])
+			minX, maxX = math.Min(minX, x), math.Max(maxX, x)
+			minY, maxY = math.Min(minY, y), math.Max(maxY, y)
+		}
+	}
+
+	f.scale = math.Min(maxWidth/(maxX-minX), maxHeight/(maxY-minY))
+	f.offsetX = -minX * f.scale
+	f.offsetY = -minY * f.scale
+	f.width = (maxX - minX) * f.scale
+	f.height = (maxY - minY) * f.scale
+	return f
+}
+
+// point returns the position of a coordinate within the map.
+func (f *frame) point(lng, lat float64) (float64, float64) {
+	x, y := f.region.projection.project(lng, lat)
+	return x*f.scale + f.offsetX, y*f.scale + f.offsetY
+}
+
+// contains returns whether a coordinate lies within the region's bounding box, give or take half a degree for places
+// on its coast or border.
+func (f *frame) contains(lng, lat float64) bool {
+	const margin = 0.5
+	return lng >= f.west-margin && lng <= f.east+margin && lat >= f.south-margin && lat <= f.north+margin
+}
+
+// path converts an outline to SVG path data.
+func (f *frame) path(outline []coordinate) string {
+	var sb strings.Builder
+	for i, c := range outline {
+		command := "L"
+		if i == 0 {
+			command = "M"
+		}
+		x, y := f.point(c[0], c[1])
+		sb.WriteString(fmt.Sprintf("%s%s %s", command, num(x), num(y)))
+	}
+	sb.WriteString("Z")
+	return sb.String()
+}
+
+// radius grows with the square root of the count, so that a dot's area is proportional to it.
+func radius(count int) float64 {
+	if count < 1 {
+		count = 1
+	}
+	return math.Min(3+2*math.Sqrt(float64(count)), 16)
+}
+
+// num formats a coordinate without any trailing zeroes.
+func num(f float64) string {
+	return strconv.FormatFloat(math.Round(f*100)/100, 'f', -1, 64)
+}
+
+// id turns a title into an element ID, so that multiple maps on a page don't collide.
+func id(title string) string {
+	var sb strings.Builder
+	sb.WriteString("map-")
+	for _, r := range strings.ToLower(title) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			sb.WriteRune(r)
+		} else {
+			sb.WriteRune('-')
+		}
+	}
+	return sb.String()
+}
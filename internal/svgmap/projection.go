@@ -0,0 +1,38 @@
+package svgmap
+
+import "math"
+
+// albers is an Albers equal-area conic projection, the standard projection of maps of the United States.
+type albers struct {
+	n, c, rho0, lng0 float64
+}
+
+// newAlbers creates a projection from its two standard parallels, and its origin.
+func newAlbers(parallel1, parallel2, originLat, originLng float64) *albers {
+	phi1 := radians(parallel1)
+	phi2 := radians(parallel2)
+
+	n := (math.Sin(phi1) + math.Sin(phi2)) / 2
+	c := math.Cos(phi1)*math.Cos(phi1) + 2*n*math.Sin(phi1)
+
+	return &albers{
+		n:    n,
+		c:    c,
+		rho0: math.Sqrt(c-2*n*math.Sin(radians(originLat))) / n,
+		lng0: originLng,
+	}
+}
+
+// project returns the position of a coordinate on the unit sphere's projected plane, with y pointing down.
+func (a *albers) project(lng, lat float64) (float64, float64) {
+	rho := math.Sqrt(a.c-2*a.n*math.Sin(radians(lat))) / a.n
+	theta := a.n * radians(lng-a.lng0)
+
+	x := rho * math.Sin(theta)
+	y := a.rho0 - rho*math.Cos(theta)
+	return x, -y
+}
+
+func radians(degrees float64) float64 {
+	return degrees * math.Pi / 180
+}
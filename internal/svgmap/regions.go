@@ -0,0 +1,72 @@
+package svgmap
+
+// coordinate is a [longitude, latitude] pair.
+type coordinate [2]float64
+
+// Region is an area that can be drawn, along with the outlines that give it context.
+type Region struct {
+	Name string
+
+	// Land and Water are simplified outlines, drawn in that order.
+	Land  [][]coordinate
+	Water [][]coordinate
+
+	// Insets are drawn in the bottom left corner of the map.
+	Insets []*Region
+
+	projection *albers
+}
+
+var conus = newAlbers(29.5, 45.5, 23, -96)
+
+// ContiguousUS is the lower 48 states.
+var ContiguousUS = &Region{
+	Name: "Contiguous United States",
+	Land: [][]coordinate{{
+		{-124.7, 48.4}, {-122.8, 49.0}, {-95.2, 49.0}, {-95.2, 49.4}, {-94.8, 49.3}, {-89.6, 48.0}, {-84.8, 46.5},
+		{-83.5, 46.1}, {-82.4, 43.0}, {-83.1, 42.0}, {-82.5, 41.7}, {-79.0, 42.7}, {-79.0, 43.3}, {-76.2, 43.6},
+		{-75.0, 44.8}, {-71.5, 45.0}, {-70.8, 45.4}, {-69.2, 47.4}, {-67.8, 47.1}, {-67.8, 45.7}, {-67.0, 44.8},
+		{-70.2, 43.6}, {-70.6, 42.6}, {-70.0, 41.8}, {-71.4, 41.4}, {-72.0, 41.1}, {-74.0, 40.5}, {-74.0, 39.7},
+		{-74.9, 38.9}, {-75.1, 38.5}, {-76.0, 36.9}, {-75.5, 35.2}, {-77.0, 34.6}, {-78.5, 33.8}, {-79.9, 32.7},
+		{-81.1, 32.0}, {-81.4, 30.7}, {-80.6, 28.4}, {-80.0, 26.7}, {-80.2, 25.3}, {-81.1, 25.1}, {-81.8, 26.1},
+		{-82.7, 27.7}, {-82.6, 28.9}, {-83.5, 29.9}, {-84.5, 30.0}, {-85.4, 29.7}, {-86.5, 30.4}, {-88.0, 30.7},
+		{-89.6, 30.2}, {-89.4, 29.0}, {-90.5, 29.1}, {-92.0, 29.6}, {-93.8, 29.7}, {-95.0, 29.3}, {-97.2, 27.7},
+		{-97.2, 25.9}, {-99.2, 26.4}, {-100.3, 28.0}, {-101.4, 29.8}, {-102.7, 29.7}, {-103.3, 29.0}, {-104.5, 29.6},
+		{-106.5, 31.8}, {-108.2, 31.8}, {-108.2, 31.3}, {-111.1, 31.3}, {-114.8, 32.5}, {-117.1, 32.5}, {-118.4, 33.8},
+		{-120.6, 34.6}, {-121.9, 36.6}, {-122.5, 37.8}, {-123.7, 39.0}, {-124.4, 40.4}, {-124.2, 42.0}, {-124.5, 42.8},
+		{-124.0, 46.3}, {-124.7, 48.4},
+	}},
+	Water:      [][]coordinate{lakeMichigan},
+	Insets:     []*Region{Hawaii},
+	projection: conus,
+}
+
+// Indiana is home to most of the section, so it gets a closer look.
+var Indiana = &Region{
+	Name: "Indiana",
+	Land: [][]coordinate{{
+		{-87.53, 41.76}, {-84.81, 41.76}, {-84.82, 39.10}, {-85.00, 38.75}, {-85.40, 38.73}, {-85.75, 38.28},
+		{-86.00, 38.00}, {-86.30, 38.15}, {-86.50, 37.95}, {-86.75, 37.95}, {-87.00, 37.90}, {-87.57, 37.97},
+		{-88.03, 37.80}, {-87.90, 38.30}, {-87.53, 38.68}, {-87.53, 41.76},
+	}},
+	Water:      [][]coordinate{lakeMichigan},
+	projection: conus,
+}
+
+// Hawaii is drawn as an inset of the contiguous US.
+var Hawaii = &Region{
+	Name: "Hawaii",
+	Land: [][]coordinate{
+		{{-159.78, 22.05}, {-159.40, 22.23}, {-159.30, 22.00}, {-159.45, 21.88}, {-159.75, 21.97}},
+		{{-158.28, 21.58}, {-157.97, 21.71}, {-157.65, 21.31}, {-157.80, 21.25}, {-158.10, 21.30}, {-158.23, 21.46}},
+		{{-157.30, 21.20}, {-156.75, 21.17}, {-156.75, 21.08}, {-157.30, 21.08}},
+		{{-156.70, 20.93}, {-156.40, 21.00}, {-156.00, 20.80}, {-156.10, 20.63}, {-156.45, 20.58}, {-156.70, 20.80}},
+		{{-155.85, 20.27}, {-155.00, 19.73}, {-154.80, 19.50}, {-155.60, 18.92}, {-155.95, 19.10}, {-156.05, 19.75}},
+	},
+	projection: newAlbers(8, 18, 3, -157),
+}
+
+var lakeMichigan = []coordinate{
+	{-87.6, 41.6}, {-86.8, 41.7}, {-86.3, 42.4}, {-86.2, 43.0}, {-86.5, 44.0}, {-85.6, 45.0}, {-84.8, 45.8},
+	{-86.0, 45.9}, {-87.0, 45.6}, {-88.0, 44.5}, {-87.6, 44.5}, {-87.9, 43.0}, {-87.8, 42.3}, {-87.6, 41.6},
+}
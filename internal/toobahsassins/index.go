@@ -0,0 +1,237 @@
+package toobahsassins
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+)
+
+var (
+	// ErrGameNotFound is returned when no game exists with the requested ID.
+	ErrGameNotFound = errors.New("no game exists with that ID")
+
+	// ErrNotEnoughPlayers is returned when creating a game with fewer than two players.
+	ErrNotEnoughPlayers = errors.New("a game needs at least two players")
+
+	// ErrPlayerNotFound is returned when the requested Purdoobah isn't playing the game.
+	ErrPlayerNotFound = errors.New("that Purdoobah isn't playing this game")
+
+	// ErrPlayerEliminated is returned when an eliminated player tries to eliminate their target.
+	ErrPlayerEliminated = errors.New("that player has already been eliminated")
+
+	// ErrGameOver is returned when recording an elimination in a game that already has a victor.
+	ErrGameOver = errors.New("the game is already over")
+)
+
+// IGameService defines a Toobahsassins Game Service
+type IGameService interface {
+	All() ([]*Game, error)
+	ByID(string) (*Game, error)
+	Create(season int, playerIDs []string) (*Game, error)
+	Eliminate(gameID, assassinID string) (*Game, error)
+	Victories() (map[string][]int, error)
+}
+
+// Game is a single season's game of Toobahsassins (a.k.a. Spoonsassins).
+//
+// Every player is secretly assigned a target, and the targets form a single ring: eliminating your target means you
+// inherit their target. The last player standing is the victor.
+type Game struct {
+	ID          string     `json:"id"`
+	Season      int        `json:"season"`
+	CreatedAt   time.Time  `json:"created_at"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+
+	// Victor is the Purdoobah ID of the last player standing, once the game is over.
+	Victor string `json:"victor,omitempty"`
+
+	// Players are in the order of the target ring.
+	Players      []*Player      `json:"players"`
+	Eliminations []*Elimination `json:"eliminations"`
+}
+
+// Player is a Purdoobah playing a game.
+type Player struct {
+	ID string `json:"id"`
+
+	// Target is the Purdoobah ID of the player this player has to eliminate next. It's a secret, and is empty once
+	// the player has been eliminated or the game is over.
+	Target string `json:"target,omitempty"`
+
+	Eliminated   bool `json:"eliminated"`
+	Eliminations int  `json:"eliminations"`
+}
+
+// Elimination is a single player eliminating their target.
+type Elimination struct {
+	Assassin string    `json:"assassin"`
+	Victim   string    `json:"victim"`
+	At       time.Time `json:"at"`
+}
+
+// NewGame deals out a random target ring to the given players.
+func NewGame(id string, season int, playerIDs []string, now time.Time) (*Game, error) {
+	// de-duplicate the players
+	unique := make([]string, 0, len(playerIDs))
+	seen := make(map[string]bool)
+	for _, playerID := range playerIDs {
+		if playerID != "" && !seen[playerID] {
+			seen[playerID] = true
+			unique = append(unique, playerID)
+		}
+	}
+	if len(unique) < 2 {
+		return &Game{}, ErrNotEnoughPlayers
+	}
+
+	// shuffle with crypto/rand, so that nobody can work out the ring from when the game was created
+	for i := len(unique) - 1; i > 0; i-- {
+		j, err := rand.Int(rand.Reader, big.NewInt(int64(i+1)))
+		if err != nil {
+			return &Game{}, err
+		}
+		unique[i], unique[j.Int64()] = unique[j.Int64()], unique[i]
+	}
+
+	g := &Game{
+		ID:           id,
+		Season:       season,
+		CreatedAt:    now,
+		Players:      make([]*Player, 0, len(unique)),
+		Eliminations: make([]*Elimination, 0),
+	}
+	for i, playerID := range unique {
+		g.Players = append(g.Players, &Player{
+			ID:     playerID,
+			Target: unique[(i+1)%len(unique)],
+		})
+	}
+
+	return g, nil
+}
+
+// IsOver returns whether the game has a victor.
+func (g *Game) IsOver() bool {
+	return g.Victor != ""
+}
+
+// Player returns the player with the given Purdoobah ID.
+func (g *Game) Player(id string) (*Player, error) {
+	for _, p := range g.Players {
+		if p.ID == id {
+			return p, nil
+		}
+	}
+	return &Player{}, fmt.Errorf("%w: `%s`", ErrPlayerNotFound, id)
+}
+
+// Remaining returns how many players haven't been eliminated yet.
+func (g *Game) Remaining() int {
+	remaining := 0
+	for _, p := range g.Players {
+		if !p.Eliminated {
+			remaining++
+		}
+	}
+	return remaining
+}
+
+// Eliminate records the assassin eliminating their current target, who passes their own target on to the assassin.
+// The game is over once a single player remains.
+func (g *Game) Eliminate(assassinID string, now time.Time) (*Elimination, error) {
+	if g.IsOver() {
+		return &Elimination{}, ErrGameOver
+	}
+
+	assassin, err := g.Player(assassinID)
+	if err != nil {
+		return &Elimination{}, err
+	}
+	if assassin.Eliminated {
+		return &Elimination{}, fmt.Errorf("%w: `%s`", ErrPlayerEliminated, assassinID)
+	}
+
+	victim, err := g.Player(assassin.Target)
+	if err != nil {
+		return &Elimination{}, err
+	}
+
+	elimination := &Elimination{Assassin: assassin.ID, Victim: victim.ID, At: now}
+	g.Eliminations = append(g.Eliminations, elimination)
+
+	assassin.Eliminations++
+	assassin.Target = victim.Target
+	victim.Eliminated = true
+	victim.Target = ""
+
+	if g.Remaining() == 1 {
+		g.Victor = assassin.ID
+		g.CompletedAt = &now
+		assassin.Target = ""
+	}
+
+	return elimination, nil
+}
+
+// Leaderboard ranks the players: those still standing first, then by eliminations, then by how long they survived.
+func (g *Game) Leaderboard() []*Player {
+	// the later a player was eliminated, the longer they survived
+	eliminatedAt := make(map[string]int)
+	for i, e := range g.Eliminations {
+		eliminatedAt[e.Victim] = i
+	}
+
+	leaderboard := make([]*Player, len(g.Players))
+	copy(leaderboard, g.Players)
+	sort.SliceStable(leaderboard, func(i, j int) bool {
+		a, b := leaderboard[i], leaderboard[j]
+		if a.Eliminated != b.Eliminated {
+			return !a.Eliminated
+		}
+		if a.Eliminations != b.Eliminations {
+			return a.Eliminations > b.Eliminations
+		}
+		if a.Eliminated {
+			return eliminatedAt[a.ID] > eliminatedAt[b.ID]
+		}
+		return a.ID < b.ID
+	})
+	return leaderboard
+}
+
+// Public returns a copy of the game that's safe to show to everyone: nobody's target is revealed, and the players are
+// listed in leaderboard order, as the order of the target ring would give the targets away.
+func (g *Game) Public() *Game {
+	public := g.Clone()
+	public.Players = public.Leaderboard()
+	for _, p := range public.Players {
+		p.Target = ""
+	}
+	return public
+}
+
+// Clone returns a deep copy of the game.
+func (g *Game) Clone() *Game {
+	clone := *g
+	if g.CompletedAt != nil {
+		completedAt := *g.CompletedAt
+		clone.CompletedAt = &completedAt
+	}
+
+	clone.Players = make([]*Player, 0, len(g.Players))
+	for _, p := range g.Players {
+		player := *p
+		clone.Players = append(clone.Players, &player)
+	}
+
+	clone.Eliminations = make([]*Elimination, 0, len(g.Eliminations))
+	for _, e := range g.Eliminations {
+		elimination := *e
+		clone.Eliminations = append(clone.Eliminations, &elimination)
+	}
+
+	return &clone
+}
@@ -1,5 +1,10 @@
 package traditions
 
+import "errors"
+
+// ErrTraditionNotFound is returned when no Tradition exists with the requested name.
+var ErrTraditionNotFound = errors.New("no Tradition exists with that name")
+
 // ITraditionService defines a Traditions Service
 type ITraditionService interface {
 	All() ([]*Tradition, error)
@@ -13,10 +13,28 @@ type Tradition struct {
 		Image struct {
 			File string `json:"file"`
 			Alt  string `json:"alt"`
+
+			// Width and Height are the size of the image in pixels, so that room can be made for it before it loads.
+			Width  int `json:"width"`
+			Height int `json:"height"`
+
+			// DominantColor is the most common color of the image, as a hex code.
+			DominantColor string `json:"dominant_color"`
+
+			// Placeholder is a tiny version of the image, as a data URL, to show blurred while the image loads. It's left
+			// out of lists, where it would add up.
+			Placeholder string `json:"placeholder,omitempty"`
 		} `json:"image"`
 	} `json:"metadata"`
 }
 
+// WithoutPlaceholder returns a copy of this Tradition without its image placeholder.
+func (t *Tradition) WithoutPlaceholder() *Tradition {
+	c := *t
+	c.Metadata.Image.Placeholder = ""
+	return &c
+}
+
 // ByName sorts Traditions by name
 type ByName []*Tradition
 
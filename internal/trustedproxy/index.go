@@ -0,0 +1,239 @@
+package trustedproxy
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/purdoobahs/purdoobahs.com/internal/httpheader"
+)
+
+// contextKey is unexported to prevent collisions with context keys defined in other packages.
+type contextKey string
+
+const clientIPContextKey = contextKey("client-ip")
+
+type TrustedProxy struct {
+	// trustedNetworks is the allowlist of networks whose forwarding headers are believed.
+	trustedNetworks []*net.IPNet
+}
+
+// NewTrustedProxy creates a TrustedProxy from a list of CIDRs (e.g. "10.0.0.0/8").
+// Bare IP addresses are also accepted and are treated as a single-address network.
+func NewTrustedProxy(cidrs []string) (*TrustedProxy, error) {
+	tp := &TrustedProxy{
+		trustedNetworks: make([]*net.IPNet, 0, len(cidrs)),
+	}
+
+	for _, cidr := range cidrs {
+		cidr = strings.TrimSpace(cidr)
+		if cidr == "" {
+			continue
+		}
+
+		// bare IP address
+		if !strings.Contains(cidr, "/") {
+			ip := net.ParseIP(cidr)
+			if ip == nil {
+				return &TrustedProxy{}, fmt.Errorf("invalid trusted proxy IP address: `%s`", cidr)
+			}
+			if ip.To4() != nil {
+				cidr = fmt.Sprintf("%s/32", cidr)
+			} else {
+				cidr = fmt.Sprintf("%s/128", cidr)
+			}
+		}
+
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return &TrustedProxy{}, fmt.Errorf("invalid trusted proxy CIDR: `%s`", cidr)
+		}
+		tp.trustedNetworks = append(tp.trustedNetworks, network)
+	}
+
+	return tp, nil
+}
+
+// RealIP is an HTTP server middleware which resolves the real client IP of the request and stores it in the request
+// context. Forwarding headers are only believed when the request was sent by a trusted proxy.
+func (tp *TrustedProxy) RealIP(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), clientIPContextKey, tp.resolve(r))
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// ClientIP returns the real client IP of the request as resolved by the RealIP middleware.
+//
+// If the middleware didn't run, the host of the request's RemoteAddr is returned instead.
+func ClientIP(r *http.Request) string {
+	if ip, ok := r.Context().Value(clientIPContextKey).(string); ok {
+		return ip
+	}
+
+	return remoteHost(r)
+}
+
+// IsTrusted returns whether the given IP address belongs to one of the trusted proxy networks.
+func (tp *TrustedProxy) IsTrusted(ip net.IP) bool {
+	if ip == nil {
+		return false
+	}
+
+	for _, network := range tp.trustedNetworks {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// resolve walks the chain of forwarding hops from right (closest to us) to left (closest to the client), skipping
+// every hop that is a trusted proxy. The first untrusted hop is the client.
+//
+// The RFC 7239 Forwarded header takes precedence over X-Forwarded-For when both are present.
+func (tp *TrustedProxy) resolve(r *http.Request) string {
+	peer := remoteHost(r)
+
+	// only a trusted proxy is allowed to tell us who the client is
+	peerIP := net.ParseIP(peer)
+	if !tp.IsTrusted(peerIP) {
+		return normalize(peerIP, peer)
+	}
+
+	var hops []string
+	if forwarded := r.Header.Values(httpheader.Forwarded.String()); len(forwarded) > 0 {
+		hops = parseForwarded(forwarded)
+	} else {
+		hops = parseXForwardedFor(r.Header.Values(httpheader.NonstandardXForwardedFor.String()))
+	}
+
+	client := normalize(peerIP, peer)
+	for i := len(hops) - 1; i >= 0; i-- {
+		ip := parseNode(hops[i])
+
+		// an unknown or obfuscated hop can't be traced past, so the last hop we trusted is the best we've got
+		if ip == nil {
+			break
+		}
+
+		client = normalize(ip, hops[i])
+		if !tp.IsTrusted(ip) {
+			break
+		}
+	}
+
+	return client
+}
+
+// parseXForwardedFor flattens every X-Forwarded-For header line into a single list of hops.
+//
+// e.g. "203.0.113.195, 70.41.3.18, 150.172.238.178"
+func parseXForwardedFor(values []string) []string {
+	var hops []string
+
+	for _, value := range values {
+		for _, hop := range strings.Split(value, ",") {
+			hops = append(hops, strings.TrimSpace(hop))
+		}
+	}
+
+	return hops
+}
+
+// parseForwarded flattens every RFC 7239 Forwarded header line into a single list of `for=` hops.
+//
+// e.g. `for=192.0.2.43, for="[2001:db8:cafe::17]:4711";proto=https;by=203.0.113.43`
+func parseForwarded(values []string) []string {
+	var hops []string
+
+	for _, value := range values {
+		for _, element := range splitQuoted(value, ',') {
+			hop := ""
+			for _, pair := range splitQuoted(element, ';') {
+				key, val, found := strings.Cut(strings.TrimSpace(pair), "=")
+				if !found || !strings.EqualFold(strings.TrimSpace(key), "for") {
+					continue
+				}
+				hop = strings.Trim(strings.TrimSpace(val), `"`)
+			}
+
+			// an element without a `for=` pair still represents a hop, just an unknown one
+			hops = append(hops, hop)
+		}
+	}
+
+	return hops
+}
+
+// splitQuoted splits s on sep, ignoring any separators that occur inside of a quoted-string.
+func splitQuoted(s string, sep rune) []string {
+	var parts []string
+
+	inQuotes := false
+	escaped := false
+	start := 0
+	for i, c := range s {
+		switch {
+		case escaped:
+			escaped = false
+		case c == '\\' && inQuotes:
+			escaped = true
+		case c == '"':
+			inQuotes = !inQuotes
+		case c == sep && !inQuotes:
+			parts = append(parts, s[start:i])
+			start = i + 1
+		}
+	}
+	parts = append(parts, s[start:])
+
+	return parts
+}
+
+// parseNode parses a single forwarding hop into an IP address, discarding any port.
+//
+// Returns nil for hops that aren't IP addresses, such as RFC 7239's "unknown" and obfuscated identifiers.
+func parseNode(node string) net.IP {
+	node = strings.TrimSpace(node)
+
+	// "[2001:db8:cafe::17]:4711" or "[2001:db8:cafe::17]"
+	if strings.HasPrefix(node, "[") {
+		end := strings.Index(node, "]")
+		if end == -1 {
+			return nil
+		}
+		return net.ParseIP(node[1:end])
+	}
+
+	// "192.0.2.43" or "2001:db8:cafe::17"
+	if ip := net.ParseIP(node); ip != nil {
+		return ip
+	}
+
+	// "192.0.2.43:47011"
+	if host, _, err := net.SplitHostPort(node); err == nil {
+		return net.ParseIP(host)
+	}
+
+	return nil
+}
+
+// remoteHost returns the host part of the request's RemoteAddr.
+func remoteHost(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// normalize returns the canonical string form of ip, or fallback if ip is nil.
+func normalize(ip net.IP, fallback string) string {
+	if ip == nil {
+		return fallback
+	}
+	return ip.String()
+}
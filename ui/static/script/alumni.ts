@@ -4,6 +4,12 @@ if (inputSearch instanceof HTMLElement) {
   inputSearch.addEventListener("input", search);
 }
 
+// the search bar filters as you type, so there's nothing to submit
+const searchBarForms: HTMLCollectionOf<Element> = document.getElementsByClassName("search-bar-form");
+for (let i = 0; i < searchBarForms.length; i++) {
+  searchBarForms.item(i)?.addEventListener("submit", (event: Event) => event.preventDefault());
+}
+
 // grab all purdoobah cards
 const purdoobahCards: HTMLCollectionOf<Element> = document.getElementsByClassName("grid-card");
 
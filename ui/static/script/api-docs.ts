@@ -0,0 +1,60 @@
+// attach click listeners to every "Try it" button
+const tryItButtons: HTMLCollectionOf<Element> = document.getElementsByClassName("try-it-button");
+for (let i = 0; i < tryItButtons.length; i++) {
+  const tryItButton = tryItButtons.item(i);
+  if (tryItButton instanceof HTMLButtonElement) {
+    tryItButton.addEventListener("click", tryIt);
+  }
+}
+
+// `tryIt` calls the endpoint the clicked button belongs to and prints the response
+async function tryIt(this: HTMLButtonElement) {
+  const endpoint: Element | null = this.closest(".endpoint");
+  if (!(endpoint instanceof HTMLElement)) {
+    return;
+  }
+
+  const output: Element | null = endpoint.querySelector(".try-it-output");
+  if (!(output instanceof HTMLPreElement)) {
+    return;
+  }
+
+  const url: string = buildUrl(endpoint);
+  output.hidden = false;
+  output.textContent = `GET ${url}\n\n…`;
+
+  try {
+    const response: Response = await fetch(url, {
+      headers: { "Accept": "application/json" },
+    });
+    // exports (e.g. CSV) aren't JSON, so they're printed as-is
+    let body: string = await response.text();
+    if ((response.headers.get("Content-Type") ?? "").startsWith("application/json")) {
+      body = JSON.stringify(JSON.parse(body), null, 2);
+    }
+    output.textContent = `GET ${url}\n\n${response.status} ${response.statusText}\n\n${body}`;
+  } catch (error) {
+    output.textContent = `GET ${url}\n\n${error}`;
+  }
+}
+
+// `buildUrl` fills in the endpoint's path template and query string with the values of its parameter inputs
+function buildUrl(endpoint: HTMLElement) {
+  let url: string = endpoint.dataset.path as string;
+  const query: URLSearchParams = new URLSearchParams();
+
+  const inputs: NodeListOf<HTMLInputElement> = endpoint.querySelectorAll<HTMLInputElement>("input[data-parameter]");
+  inputs.forEach((input: HTMLInputElement) => {
+    const parameter: string = input.dataset.parameter as string;
+    if (input.dataset.in === "query") {
+      if (input.value !== "") {
+        query.set(parameter, input.value);
+      }
+      return;
+    }
+    url = url.replace(`{${parameter}}`, encodeURIComponent(input.value));
+  });
+
+  const queryString: string = query.toString();
+  return queryString === "" ? url : `${url}?${queryString}`;
+}